@@ -215,38 +215,41 @@ func TestDevTUI_fetchAndReconstructState_Stripping(t *testing.T) {
 	}
 }
 
-func TestDevTUI_clearRemoteHandlers(t *testing.T) {
+func TestDevTUI_reconcileRemoteHandlers_RemovesRemoteFields(t *testing.T) {
 	tui := &DevTUI{
 		TuiConfig: &TuiConfig{},
 	}
+	d := newRemoteDaemon(tui, Endpoint{})
 	section := &tabSection{Title: "Test"}
 	tui.TabSections = []*tabSection{section}
 
+	remote := func() *field { return &field{isRemote: true, remote: &remoteField{daemon: d}} }
 	section.FieldHandlers = []*field{
 		{isRemote: false},
-		{isRemote: true},
+		remote(),
 		{isRemote: false},
-		{isRemote: true},
+		remote(),
 	}
 
-	tui.clearRemoteHandlers()
+	d.reconcileRemoteHandlers(nil)
 
 	if len(section.FieldHandlers) != 2 {
-		t.Errorf("Expected 2 handlers after clearing, got %d", len(section.FieldHandlers))
+		t.Errorf("Expected 2 handlers after reconciling, got %d", len(section.FieldHandlers))
 	}
 	for _, f := range section.FieldHandlers {
 		if f.isRemote {
-			t.Errorf("Found remote handler after clearing")
+			t.Errorf("Found remote handler after reconciling")
 		}
 	}
 }
 
-func TestDevTUI_reconstructRemoteHandlers(t *testing.T) {
+func TestDevTUI_reconcileRemoteHandlers(t *testing.T) {
 	tui := &DevTUI{
 		TuiConfig: &TuiConfig{
 			ClientURL: "http://localhost:3030/logs",
 		},
 	}
+	d := newRemoteDaemon(tui, Endpoint{URL: tui.ClientURL})
 	section := &tabSection{Title: "App"}
 	tui.TabSections = []*tabSection{section}
 
@@ -255,7 +258,7 @@ func TestDevTUI_reconstructRemoteHandlers(t *testing.T) {
 		{TabTitle: "Other", HandlerName: "Log", HandlerType: 1},
 	}
 
-	tui.reconstructRemoteHandlers(entries)
+	d.reconcileRemoteHandlers(entries)
 
 	// entries[1] should be ignored (tab title mismatch)
 	if len(section.FieldHandlers) != 1 {
@@ -0,0 +1,235 @@
+package devtui
+
+import (
+	"slices"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+// Command palette (Ctrl+P): an overlay replacing the content area that
+// fuzzy-searches every tab title, field Label()/Name() and shortcut description.
+// Choosing an entry goes through navigateTo / executeShortcut like any other key.
+
+// paletteKind identifies what a palette entry does when chosen.
+type paletteKind int
+
+const (
+	paletteTab      paletteKind = iota // activate a tab
+	paletteField                       // select a field of a tab
+	paletteShortcut                    // run a registered shortcut
+)
+
+// paletteItem is one searchable entry of the command palette.
+type paletteItem struct {
+	kind       paletteKind
+	text       string // searched and displayed text
+	detail     string // right-hand hint (tab title, shortcut key)
+	tabIndex   int
+	fieldIndex int
+	shortcut   *ShortcutEntry
+}
+
+// commandPalette holds the state of the open palette (DevTUI.palette, nil when closed).
+type commandPalette struct {
+	query    []rune
+	items    []paletteItem
+	matches  []paletteItem // items matching query, best first
+	selected int
+}
+
+// paletteItems collects every tab, field and shortcut of the TUI.
+func (h *DevTUI) paletteItems() []paletteItem {
+	var items []paletteItem
+	for _, ts := range h.TabSections {
+		items = append(items, paletteItem{kind: paletteTab, text: ts.Title, detail: "tab", tabIndex: ts.Index, fieldIndex: -1})
+		for i, f := range ts.FieldHandlers {
+			if f.handler == nil {
+				continue
+			}
+			text := f.handler.Label()
+			if name := f.handler.Name(); name != "" && name != text {
+				text = Convert(text + " " + name).TrimSpace().String()
+			}
+			items = append(items, paletteItem{kind: paletteField, text: text, detail: ts.Title, tabIndex: ts.Index, fieldIndex: i})
+		}
+	}
+
+	for _, entry := range h.shortcutRegistry.Entries() {
+		detail := "key " + entry.Key
+		if entry.Scope != ScopeGlobal && entry.TabIndex < len(h.TabSections) {
+			detail += " in " + h.TabSections[entry.TabIndex].Title
+		}
+		items = append(items, paletteItem{kind: paletteShortcut, text: entry.Description, detail: detail, tabIndex: entry.TabIndex, fieldIndex: entry.FieldIndex, shortcut: entry})
+	}
+	return items
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of text.
+// Higher scores are better: consecutive runs and word starts are rewarded,
+// gaps are penalised. ok is false when query is not a subsequence of text.
+func fuzzyScore(query, text []rune) (score int, ok bool) {
+	if len(query) == 0 {
+		return 0, true
+	}
+	qi, last := 0, -1
+	for ti := 0; ti < len(text) && qi < len(query); ti++ {
+		if unicode.ToLower(text[ti]) != unicode.ToLower(query[qi]) {
+			continue
+		}
+		score += 1
+		switch {
+		case last >= 0 && ti == last+1:
+			score += 5 // consecutive
+		case ti == 0 || !unicode.IsLetter(text[ti-1]) && !unicode.IsDigit(text[ti-1]):
+			score += 3 // start of word
+		case last >= 0:
+			score -= min(ti-last-1, 3) // gap
+		}
+		last = ti
+		qi++
+	}
+	if qi < len(query) {
+		return 0, false
+	}
+	return score, true
+}
+
+// filter recomputes the matches for the current query, best score first
+// (ties keep the palette order: tabs, fields, shortcuts).
+func (p *commandPalette) filter() {
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var found []scored
+	for _, it := range p.items {
+		if s, ok := fuzzyScore(p.query, []rune(it.text)); ok {
+			found = append(found, scored{it, s})
+		}
+	}
+	slices.SortStableFunc(found, func(a, b scored) int { return b.score - a.score })
+	p.matches = p.matches[:0]
+	for _, f := range found {
+		p.matches = append(p.matches, f.item)
+	}
+	if p.selected >= len(p.matches) {
+		p.selected = max(len(p.matches)-1, 0)
+	}
+}
+
+// openPalette shows the command palette with an empty query.
+func (h *DevTUI) openPalette() {
+	h.palette = &commandPalette{items: h.paletteItems()}
+	h.palette.filter()
+}
+
+// closePalette hides the command palette.
+func (h *DevTUI) closePalette() {
+	h.palette = nil
+}
+
+// handlePaletteKeyboard handles every key while the palette is open.
+func (h *DevTUI) handlePaletteKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
+	p := h.palette
+	if h.keymap.action(keyStroke(msg)) == ActionPalette {
+		h.closePalette()
+		return false, nil
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		h.closePalette()
+	case tea.KeyUp:
+		if p.selected > 0 {
+			p.selected--
+		}
+	case tea.KeyDown:
+		if p.selected < len(p.matches)-1 {
+			p.selected++
+		}
+	case tea.KeyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	case tea.KeySpace:
+		p.query = append(p.query, ' ')
+		p.filter()
+	case tea.KeyRunes:
+		p.query = append(p.query, msg.Runes...)
+		p.filter()
+	case tea.KeyEnter:
+		if len(p.matches) == 0 {
+			return false, nil
+		}
+		item := p.matches[p.selected]
+		h.closePalette()
+		return h.runPaletteItem(item)
+	case tea.KeyCtrlC:
+		h.closePalette()
+		return h.handleNormalModeKeyboard(msg)
+	}
+	return false, nil
+}
+
+// runPaletteItem navigates to or executes the chosen palette entry.
+func (h *DevTUI) runPaletteItem(item paletteItem) (bool, tea.Cmd) {
+	if item.kind == paletteShortcut {
+		return h.executeShortcut(item.shortcut)
+	}
+	h.navigateTo(item.tabIndex, item.fieldIndex)
+	h.updateViewport()
+	h.checkAndTriggerInteractiveContent()
+	return false, nil
+}
+
+// paletteView renders the palette in place of the viewport, using the same height.
+func (h *DevTUI) paletteView() string {
+	p := h.palette
+	height := max(h.viewport.Height, 3)
+	width := h.viewport.Width
+
+	label := "palette"
+	if keys := h.keymap.keys(ActionPalette); len(keys) > 0 {
+		label = keys[0]
+	}
+	prompt := h.headerTitleStyle.Render(label) + " " + string(p.query)
+	if h.cursorVisible {
+		prompt += "█"
+	}
+	lines := []string{prompt, h.lineHeadFootStyle.Render(Convert("─").Repeat(max(width, 1)).String())}
+
+	visible := height - len(lines)
+	first := 0
+	if p.selected >= visible {
+		first = p.selected - visible + 1
+	}
+	if len(p.matches) == 0 {
+		lines = append(lines, h.textContentStyle.Render("  no matches"))
+	}
+	for i := first; i < len(p.matches) && len(lines) < height; i++ {
+		it := p.matches[i]
+		marker := "  "
+		if i == p.selected {
+			marker = "▶ "
+		}
+		detail := h.timeStyle.Render(it.detail)
+		text := it.text
+		if room := width - lipgloss.Width(marker) - lipgloss.Width(detail) - 2; room > 0 && lipgloss.Width(text) > room {
+			text = Convert(text).Truncate(room, 0).String()
+		}
+		line := marker + text + "  " + detail
+		if i == p.selected {
+			line = h.textContentStyle.Bold(true).Render(line)
+		} else {
+			line = h.textContentStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return Convert(lines).Join("\n").String()
+}
@@ -0,0 +1,100 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testModeHandler is an editable handler exposing shortcuts.
+type testModeHandler struct {
+	*TestEditableHandler
+	shortcuts []map[string]string
+}
+
+func (h *testModeHandler) Shortcuts() []map[string]string { return h.shortcuts }
+
+func typeQuery(h *DevTUI, query string) {
+	for _, r := range query {
+		if r == ' ' {
+			h.handleKeyboard(tea.KeyMsg{Type: tea.KeySpace})
+			continue
+		}
+		h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore([]rune("dpl"), []rune("DEPLOY")); !ok {
+		t.Error("dpl should match DEPLOY")
+	}
+	if _, ok := fuzzyScore([]rune("xyz"), []rune("DEPLOY")); ok {
+		t.Error("xyz should not match DEPLOY")
+	}
+	consecutive, _ := fuzzyScore([]rune("port"), []rune("Server Port"))
+	scattered, _ := fuzzyScore([]rune("port"), []rune("Project Output Root Tree"))
+	if consecutive <= scattered {
+		t.Errorf("consecutive match should score higher: %d <= %d", consecutive, scattered)
+	}
+}
+
+func TestCommandPalette_NavigateToField(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 80, 20
+	h.NewTabSection("LOGS", "Logs")
+	config := h.NewTabSection("CONFIG", "Config")
+	h.AddHandler(NewTestEditableHandler("Host", "localhost"), "", config)
+	h.AddHandler(NewTestEditableHandler("Server Port", "8080"), "", config)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if h.palette == nil {
+		t.Fatal("Ctrl+P should open the palette")
+	}
+	typeQuery(h, "sport")
+
+	if !strings.Contains(h.paletteView(), "Server Port") {
+		t.Error("palette view should list the matching field")
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if h.palette != nil {
+		t.Error("Enter should close the palette")
+	}
+	if h.activeTab != config.(*tabSection).Index || config.(*tabSection).IndexActiveEditField != 1 {
+		t.Errorf("expected CONFIG tab field 1, got tab %d field %d", h.activeTab, config.(*tabSection).IndexActiveEditField)
+	}
+}
+
+func TestCommandPalette_ExecutesShortcut(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.NewTabSection("LOGS", "Logs")
+	build := h.NewTabSection("BUILD", "Build")
+	mode := &testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", "c"),
+		shortcuts:           []map[string]string{{"c": "coding mode"}, {"d": "debug mode"}},
+	}
+	h.AddHandler(mode, "", build)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeQuery(h, "debug")
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got := mode.Value(); got != "d" {
+		t.Errorf("choosing the shortcut should run Change(\"d\"), value is %q", got)
+	}
+	if h.activeTab != build.(*tabSection).Index {
+		t.Errorf("shortcut should navigate to BUILD, active tab is %d", h.activeTab)
+	}
+}
+
+func TestCommandPalette_EscCloses(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.NewTabSection("LOGS", "Logs")
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeQuery(h, "q")
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEsc})
+	if h.palette != nil {
+		t.Error("Esc should close the palette")
+	}
+}
@@ -0,0 +1,80 @@
+package devtui
+
+// Tracked message history: a handler's tracked line is replaced in place on each
+// update (see updateOrAddContent). The replaced versions are kept per
+// tabContent.Id so a mouse click on the line can expand them underneath.
+
+// maxHistoryPerLine bounds the versions kept for one tracked line.
+const maxHistoryPerLine = 20
+
+// maxTabContents bounds the messages kept per tab, to prevent memory issues and slow rendering.
+const maxTabContents = 500
+
+// recordHistory keeps the version of a tracked line about to be replaced.
+// Animation ticks ("Deploying . .") are never kept as versions.
+// Must be called with ts.mu held.
+func (ts *tabSection) recordHistory(previous tabContent) {
+	if previous.animationFrame {
+		return
+	}
+	if ts.history == nil {
+		ts.history = make(map[string][]tabContent)
+	}
+	versions := append(ts.history[previous.Id], previous)
+	if len(versions) > maxHistoryPerLine {
+		versions = versions[len(versions)-maxHistoryPerLine:]
+	}
+	ts.history[previous.Id] = versions
+}
+
+// trimContents keeps the last maxTabContents messages, forgetting the history
+// and expanded state of the dropped ones. Must be called with ts.mu held.
+func (ts *tabSection) trimContents() {
+	drop := len(ts.tabContents) - maxTabContents
+	if drop <= 0 {
+		return
+	}
+	for _, c := range ts.tabContents[:drop] {
+		delete(ts.history, c.Id)
+		delete(ts.expanded, c.Id)
+	}
+	ts.tabContents = ts.tabContents[drop:]
+}
+
+// historyOf returns the previous versions of a tracked line (oldest first) and
+// whether they are expanded.
+func (ts *tabSection) historyOf(id string) ([]tabContent, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return ts.history[id], ts.expanded[id]
+}
+
+// toggleHistory expands or collapses the history under a line.
+// Returns false when the line has no history.
+func (ts *tabSection) toggleHistory(id string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if len(ts.history[id]) == 0 {
+		return false
+	}
+	if ts.expanded == nil {
+		ts.expanded = make(map[string]bool)
+	}
+	ts.expanded[id] = !ts.expanded[id]
+	return true
+}
+
+// historyLines renders the expanded history of a line, newest first, indented
+// under it, with the version each line shows.
+func (h *DevTUI) historyLines(ts *tabSection, id string) (lines []string, shown []tabContent) {
+	versions, expanded := ts.historyOf(id)
+	if !expanded {
+		return nil, nil
+	}
+	lines = make([]string, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		lines = append(lines, h.timeStyle.Render("  ↳ ")+h.textContentStyle.Faint(true).Render(h.formatMessage(versions[i], true)))
+		shown = append(shown, versions[i])
+	}
+	return lines, shown
+}
@@ -0,0 +1,192 @@
+package devtui
+
+import (
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+// Copy mode ("copy_mode" keymap action, Ctrl+Y): select one or more messages of
+// the active tab and copy their plain text (no timestamp, no handler badge) to
+// the system clipboard with an OSC 52 escape sequence. OSC 52 is handled by the
+// terminal itself, so it works over SSH and needs no xclip/pbcopy.
+// The "copy_field" action (Alt+C) copies the selected field value the same way.
+
+// copySelection is the state of copy mode (DevTUI.copyMode, nil when off).
+// It holds tabContent Ids, resolved against the active tab contents when
+// rendering or copying: new, updated or trimmed messages don't shift it.
+type copySelection struct {
+	cursor string
+	mark   string // start of a range selection ("" = only the cursor line)
+}
+
+// bounds returns the selected range of contents, inclusive. A cursor no longer
+// in contents falls back to the newest message, a lost mark to the cursor line.
+// Requires a non-empty contents.
+func (c *copySelection) bounds(contents []tabContent) (lo, hi int) {
+	cursor := c.cursorIndex(contents)
+	mark := contentIndex(contents, c.mark)
+	if mark < 0 {
+		return cursor, cursor
+	}
+	return min(mark, cursor), max(mark, cursor)
+}
+
+// cursorIndex returns the index of the cursor message in contents.
+func (c *copySelection) cursorIndex(contents []tabContent) int {
+	if i := contentIndex(contents, c.cursor); i >= 0 {
+		return i
+	}
+	return len(contents) - 1
+}
+
+// contentIndex returns the index of the message with the given Id, or -1.
+func contentIndex(contents []tabContent, id string) int {
+	if id == "" {
+		return -1
+	}
+	return slices.IndexFunc(contents, func(c tabContent) bool { return c.Id == id })
+}
+
+// enterCopyMode starts copy mode on the newest message of the active tab.
+func (h *DevTUI) enterCopyMode() {
+	contents := h.TabSections[h.activeTab].contentsSnapshot()
+	if len(contents) == 0 {
+		return
+	}
+	h.copyMode = &copySelection{cursor: contents[len(contents)-1].Id}
+	h.updateViewport()
+}
+
+// exitCopyMode leaves copy mode.
+func (h *DevTUI) exitCopyMode() {
+	h.copyMode = nil
+	h.updateViewport()
+}
+
+// handleCopyModeKeyboard moves the cursor (scroll keys), starts/clears a range
+// (Space), copies (Enter) or leaves (Esc, copy_mode key).
+func (h *DevTUI) handleCopyModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
+	sel := h.copyMode
+	contents := h.TabSections[h.activeTab].contentsSnapshot()
+	last := len(contents) - 1
+	if last < 0 {
+		h.exitCopyMode()
+		return false, nil
+	}
+
+	cursor := sel.cursorIndex(contents)
+	switch action := h.keymap.action(keyStroke(msg)); {
+	case action == ActionCopyMode || msg.Type == tea.KeyEsc:
+		h.exitCopyMode()
+		return false, nil
+	case action == ActionQuit:
+		h.copyMode = nil
+		return h.handleNormalModeKeyboard(msg)
+	case action == ActionScrollUp:
+		cursor--
+	case action == ActionScrollDown:
+		cursor++
+	case action == ActionPageUp:
+		cursor -= max(h.viewport.Height, 1)
+	case action == ActionPageDown:
+		cursor += max(h.viewport.Height, 1)
+	case action == ActionPin:
+		h.pinSelection()
+		return false, nil
+	case action == ActionToggleGroup:
+		if contentIndex(contents, sel.mark) < 0 {
+			sel.mark = contents[cursor].Id
+		} else {
+			sel.mark = ""
+		}
+	case action == ActionEdit:
+		lo, hi := sel.bounds(contents)
+		lines := make([]string, 0, hi-lo+1)
+		for _, c := range contents[lo : hi+1] {
+			lines = append(lines, c.Content)
+		}
+		h.copyMode = nil
+		h.copyToClipboard(Convert(lines).Join("\n").String(), Sprintf("%d message(s)", len(lines)))
+		h.updateViewport()
+		return false, nil
+	}
+	sel.cursor = contents[min(max(cursor, 0), last)].Id
+	h.updateViewport()
+	return false, nil
+}
+
+// copyModeLine marks the rendered message at index of the flat view as selected
+// (lo..hi, see copySelection.bounds) / under the cursor, and anchors the viewport
+// on the cursor.
+func (h *DevTUI) copyModeLine(rendered string, index, lo, hi, cursor int) string {
+	marker := "  "
+	if index >= lo && index <= hi {
+		marker = h.infoStyle.Render("▌ ")
+	}
+	if index == cursor {
+		marker = h.infoStyle.Render("▶ ")
+		h.contentAnchor = len(h.contentLineIds)
+	}
+	return marker + rendered
+}
+
+// copyFieldValue copies the value of the selected field of the active tab.
+func (h *DevTUI) copyFieldValue() {
+	tab := h.TabSections[h.activeTab]
+	if tab.IndexActiveEditField >= len(tab.FieldHandlers) {
+		return
+	}
+	f := tab.FieldHandlers[tab.IndexActiveEditField]
+	h.copyToClipboard(f.Value(), "field value")
+}
+
+// copyToClipboard writes text to the clipboard and reports it in the active tab
+// (one tracked "Clipboard" line).
+func (h *DevTUI) copyToClipboard(text, what string) {
+	tab := h.TabSections[h.activeTab]
+	if err := h.writeClipboard(text); err != nil {
+		h.sendMessageWithHandler("Copy failed: "+err.Error(), Msg.Error, tab, "Clipboard", "Clipboard", "", handlerTypeLoggable)
+		return
+	}
+	h.sendMessageWithHandler("Copied "+what+" to clipboard", Msg.Success, tab, "Clipboard", "Clipboard", "", handlerTypeLoggable)
+}
+
+// terminalOutput is the output of the tea program (os.Stdout), shared with the
+// OSC 52 writes: the lock keeps a sequence from landing inside a rendered frame.
+// The embedded *os.File lets bubbletea detect the terminal and its size.
+type terminalOutput struct {
+	mu sync.Mutex
+	*os.File
+}
+
+func (t *terminalOutput) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.Write(p)
+}
+
+func (t *terminalOutput) WriteString(s string) (int, error) {
+	return t.Write([]byte(s))
+}
+
+// writeClipboard emits the OSC 52 sequence, wrapped for tmux/screen when needed.
+func (h *DevTUI) writeClipboard(text string) error {
+	var out io.Writer = os.Stdout
+	if h.clipboardOut != nil {
+		out = h.clipboardOut
+	}
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(out)
+	return err
+}
@@ -0,0 +1,107 @@
+package devtui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+// clipboardText decodes the payload of the OSC 52 sequence written to out.
+func clipboardText(t *testing.T, out *bytes.Buffer) string {
+	t.Helper()
+	seq := out.String()
+	start := strings.Index(seq, ";c;")
+	end := strings.LastIndex(seq, "\a")
+	if start < 0 || end < start {
+		t.Fatalf("no OSC 52 sequence written: %q", seq)
+	}
+	data, err := base64.StdEncoding.DecodeString(seq[start+3 : end])
+	if err != nil {
+		t.Fatalf("invalid OSC 52 payload %q: %v", seq, err)
+	}
+	return string(data)
+}
+
+func TestCopyMode_CopiesPlainTextRange(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 10
+	var out bytes.Buffer
+	h.clipboardOut = &out
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+
+	h.sendMessageWithHandler("ok: TestA", Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("panic: nil map", Msg.Error, tab, "", "", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("\tmain.go:12", Msg.Error, tab, "", "", "", handlerTypeLoggable)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlY})
+	if h.copyMode == nil || h.copyMode.cursor != tab.contentsSnapshot()[2].Id {
+		t.Fatalf("Ctrl+Y should start copy mode on the newest message, got %+v", h.copyMode)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeySpace}) // mark
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyUp})
+	if !strings.Contains(h.ContentView(), "▶") {
+		t.Error("copy mode should show the cursor")
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if got := clipboardText(t, &out); got != "panic: nil map\n\tmain.go:12" {
+		t.Errorf("expected the plain text of the two selected messages, got %q", got)
+	}
+	if h.copyMode != nil {
+		t.Error("copying should leave copy mode")
+	}
+}
+
+func TestCopyMode_CopiesFieldValue(t *testing.T) {
+	t.Setenv("TMUX", "")
+	h := DefaultTUIForTest()
+	var out bytes.Buffer
+	h.clipboardOut = &out
+	tab := h.NewTabSection("CONFIG", "")
+	h.AddHandler(NewTestEditableHandler("DSN", "postgres://localhost/db"), "", tab)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}, Alt: true})
+	if got := clipboardText(t, &out); got != "postgres://localhost/db" {
+		t.Errorf("Alt+C should copy the field value, got %q", got)
+	}
+}
+
+func TestCopyMode_SelectionFollowsMessages(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 10
+	var out bytes.Buffer
+	h.clipboardOut = &out
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+
+	h.sendMessageWithHandler("building", Msg.Info, tab, "Builder", "Builder", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("panic: nil map", Msg.Error, tab, "", "", "", handlerTypeLoggable)
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlY})
+
+	// A tracked update moves "Builder" to the end and a new line arrives: the
+	// cursor stays on the panic line
+	h.sendMessageWithHandler("build ok", Msg.Success, tab, "Builder", "Builder", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("watching", Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}, Alt: true})
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 1 || pins[0].Content != "panic: nil map" {
+		t.Errorf("Alt+P should pin the line under the cursor, got %+v", pins)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := clipboardText(t, &out); got != "panic: nil map" {
+		t.Errorf("expected the line under the cursor, got %q", got)
+	}
+}
+
+func TestCopyMode_ClipboardSharesProgramOutput(t *testing.T) {
+	h := NewTUI(&TuiConfig{})
+	if _, ok := h.clipboardOut.(*terminalOutput); !ok {
+		t.Errorf("OSC 52 writes should go through the program's output, got %T", h.clipboardOut)
+	}
+}
@@ -16,6 +16,7 @@ type field struct {
 	cursor        int          // cursor position in text value
 	viewport      TextViewport // Manages horizontal scroll
 	isRemote      bool         // true when populated via SSE state reconstruction
+	remote        *remoteField // daemon state and action outcome of a remote field (see remote_actions.go)
 }
 
 // setTempEditValueForTest permite modificar tempEditValue en tests
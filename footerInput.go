@@ -67,6 +67,16 @@ func (h *DevTUI) footerView() string {
 func (h *DevTUI) renderScrollInfo() string {
 	var scrollIcon string
 
+	if h.copyMode != nil {
+		return h.footerInfoStyle.Render(" COPY")
+	}
+
+	// Pending chord shortcut ("g d" after pressing "g"): show the typed strokes instead
+	if len(h.pendingChord) > 0 {
+		chord := fmt.Convert(fmt.Convert(h.pendingChord).Join(" ").String()+"…").Truncate(PaginationColumnWidth, 0).String()
+		return h.footerInfoStyle.Render(lipgloss.NewStyle().Width(PaginationColumnWidth).Align(lipgloss.Center).Render(chord))
+	}
+
 	atTop := h.viewport.AtTop()
 	atBottom := h.viewport.AtBottom()
 
@@ -1,6 +1,8 @@
 package devtui
 
 import (
+	"time"
+
 	"github.com/tinywasm/fmt"
 	"github.com/tinywasm/fmt/lang"
 )
@@ -229,14 +231,28 @@ func (ts *tabSection) registerLoggableHandler(handler Loggable, color string) {
 			trackingID = currentName
 		}
 
+		if isOpening {
+			ts.beginOperation(currentName)
+		}
+		elapsed, timed := time.Duration(0), false
+		if isClosing {
+			if elapsed, timed = ts.operationElapsed(currentName); timed {
+				messageStr += " (" + formatElapsed(elapsed) + ")"
+			}
+		}
+
 		// Send to DevTUI
-		ts.tui.sendMessageWithHandler(messageStr, msgType, ts, currentName, trackingID, color, hType)
+		ts.tui.sendTimedMessage(messageStr, msgType, ts, currentName, trackingID, color, hType, elapsed)
 
 		// Handle animation
 		if isOpening {
 			ts.startAnimation(currentName, messageStr, msgType, color)
 		} else if isClosing {
 			ts.stopAnimation(currentName)
+			if timed {
+				ts.recordOperation(currentName, elapsed)
+			}
+			ts.endOperation(currentName)
 		} else if trackingID == "" {
 			// Regular streaming message: stop any pending animation
 			ts.stopAnimation(currentName)
@@ -253,22 +269,33 @@ func (ts *tabSection) registerLoggableHandler(handler Loggable, color string) {
 
 // registerShortcutsIfSupported checks if handler implements shortcut interface and registers shortcuts
 func (ts *tabSection) registerShortcutsIfSupported(handler HandlerEdit, fieldIndex int) {
-	// Check if handler implements shortcut interface
-	if shortcutProvider, hasShortcuts := handler.(ShortcutProvider); hasShortcuts {
-		shortcuts := shortcutProvider.Shortcuts()
+	var shortcuts []ScopedShortcut
+	if scoped, ok := handler.(ScopedShortcutProvider); ok {
+		shortcuts = scoped.ScopedShortcuts()
+	} else if shortcutProvider, hasShortcuts := handler.(ShortcutProvider); hasShortcuts {
 		// shortcuts is an ordered slice of single-entry maps to preserve registration order
-		for _, m := range shortcuts {
+		for _, m := range shortcutProvider.Shortcuts() {
 			for key, description := range m {
-				entry := &ShortcutEntry{
-					Key:         key,
-					Description: description,
-					TabIndex:    ts.Index,
-					FieldIndex:  fieldIndex,
-					HandlerName: handler.Name(),
-					Value:       key, // Use the key as the value by default
-				}
-				ts.tui.shortcutRegistry.Register(key, entry)
+				shortcuts = append(shortcuts, ScopedShortcut{Key: key, Description: description})
 			}
 		}
 	}
+	for _, s := range shortcuts {
+		key, enabled := ts.tui.keymap.shortcutKey(handler.Name(), s.Key) // keymap file may remap or disable it
+		if !enabled {
+			continue
+		}
+		entry := &ShortcutEntry{
+			Key:         key,
+			Description: s.Description,
+			Scope:       s.Scope,
+			TabIndex:    ts.Index,
+			FieldIndex:  fieldIndex,
+			HandlerName: handler.Name(),
+			Value:       s.Key, // Use the key as the value by default
+		}
+		if err := ts.tui.shortcutRegistry.TryRegister(key, entry); err != nil && ts.tui.Logger != nil {
+			ts.tui.Logger(err)
+		}
+	}
 }
@@ -0,0 +1,176 @@
+package devtui
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+// Grouped view: instead of a flat message list, ContentView renders one
+// collapsible header per handler with a status badge taken from the handler's
+// last message. Toggled per tab with Ctrl+G; Up/Down select a header and Space
+// expands or collapses it.
+
+// groupStatus is the badge shown next to a handler group header.
+type groupStatus int
+
+const (
+	groupStatusOK groupStatus = iota
+	groupStatusRunning
+	groupStatusError
+)
+
+// handlerGroup collects the messages of a single handler for the grouped view.
+type handlerGroup struct {
+	name     string // RawHandlerName ("" for messages without handler)
+	color    string
+	messages []tabContent
+	status   groupStatus
+}
+
+// handlerGroups splits contents by RawHandlerName. Groups keep the order in which
+// their handler first logged in this tab (ts.groupOrder), so tracked updates (which
+// move the message to the end of tabContents) don't make headers jump around.
+func (ts *tabSection) handlerGroups(contents []tabContent) []handlerGroup {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	byName := make(map[string]*handlerGroup)
+	for _, c := range contents {
+		g, ok := byName[c.RawHandlerName]
+		if !ok {
+			g = &handlerGroup{name: c.RawHandlerName}
+			byName[c.RawHandlerName] = g
+			if !slices.Contains(ts.groupOrder, c.RawHandlerName) {
+				ts.groupOrder = append(ts.groupOrder, c.RawHandlerName)
+			}
+		}
+		g.color = c.handlerColor
+		g.messages = append(g.messages, c)
+	}
+
+	groups := make([]handlerGroup, 0, len(byName))
+	for _, name := range ts.groupOrder {
+		g, ok := byName[name]
+		if !ok {
+			continue
+		}
+		last := g.messages[len(g.messages)-1]
+		switch {
+		case ts.animationStopChans[name] != nil:
+			g.status = groupStatusRunning
+		case last.Type == Msg.Error:
+			g.status = groupStatusError
+		default:
+			g.status = groupStatusOK
+		}
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// groupedContentLines renders the handler groups of a tab and records the line of
+// the selected header in h.contentAnchor so updateViewport keeps it visible.
+func (h *DevTUI) groupedContentLines(section *tabSection, contents []tabContent, firstLine int) []string {
+	groups := section.handlerGroups(contents)
+	if len(groups) == 0 {
+		return nil
+	}
+	if section.selectedGroup >= len(groups) {
+		section.selectedGroup = len(groups) - 1
+	}
+	if section.selectedGroup < 0 {
+		section.selectedGroup = 0
+	}
+
+	var lines []string
+	line := firstLine
+	for i, g := range groups {
+		header := h.renderGroupHeader(g, section.collapsedGroups[g.name], i == section.selectedGroup)
+		if i == section.selectedGroup {
+			h.contentAnchor = line
+		}
+		lines = append(lines, header)
+		line += lipgloss.Height(header)
+
+		if section.collapsedGroups[g.name] {
+			continue
+		}
+		for _, content := range g.messages {
+			rendered := h.textContentStyle.PaddingLeft(3).Render(h.formatMessage(content, true))
+			lines = append(lines, rendered)
+			line += lipgloss.Height(rendered)
+		}
+	}
+	return lines
+}
+
+// renderGroupHeader renders "▾ NAME [ ERR ] 3" for one handler group.
+func (h *DevTUI) renderGroupHeader(g handlerGroup, collapsed, selected bool) string {
+	arrow := "▾"
+	if collapsed {
+		arrow = "▸"
+	}
+
+	name := g.name
+	if name == "" {
+		name = "general"
+	}
+
+	var badge string
+	switch g.status {
+	case groupStatusRunning:
+		badge = h.infoStyle.Render("[ RUN ]")
+	case groupStatusError:
+		badge = h.errStyle.Render("[ ERR ]")
+	default:
+		badge = h.successStyle.Render("[ OK  ]")
+	}
+
+	header := Sprintf("%s %s %s %d", arrow, h.formatHandlerName(padHandlerName(name, HandlerNameWidth), g.color), badge, len(g.messages))
+	if selected {
+		return h.textContentStyle.Bold(true).Underline(true).Render(header)
+	}
+	return h.textContentStyle.Render(header)
+}
+
+// toggleGroupedView switches the active tab between the flat and the grouped view.
+func (h *DevTUI) toggleGroupedView() {
+	if len(h.TabSections) == 0 {
+		return
+	}
+	section := h.TabSections[h.activeTab]
+	section.groupedView = !section.groupedView
+	h.updateViewport()
+}
+
+// moveGroupSelection moves the selected handler header by delta (wraps around).
+func (h *DevTUI) moveGroupSelection(delta int) {
+	section := h.TabSections[h.activeTab]
+	contents := section.contentsSnapshot()
+
+	total := len(section.handlerGroups(contents))
+	if total == 0 {
+		return
+	}
+	section.selectedGroup = (section.selectedGroup + delta + total) % total
+	h.updateViewport()
+}
+
+// toggleSelectedGroup expands or collapses the selected handler header.
+func (h *DevTUI) toggleSelectedGroup() {
+	section := h.TabSections[h.activeTab]
+	contents := section.contentsSnapshot()
+
+	groups := section.handlerGroups(contents)
+	if section.selectedGroup < 0 || section.selectedGroup >= len(groups) {
+		return
+	}
+	if section.collapsedGroups == nil {
+		section.collapsedGroups = make(map[string]bool)
+	}
+	name := groups[section.selectedGroup].name
+	section.collapsedGroups[name] = !section.collapsedGroups[name]
+	h.updateViewport()
+}
@@ -0,0 +1,86 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+func TestHandlerGroups_OrderAndStatus(t *testing.T) {
+	h := DefaultTUIForTest()
+	tab := h.NewTabSection("BUILD", "Monorepo build").(*tabSection)
+
+	h.sendMessageWithHandler("compiling", Msg.Info, tab, "pkgA", "pkgA", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("build failed", Msg.Error, tab, "pkgB", "pkgB", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("deploying", Msg.Info, tab, "pkgC", "pkgC", "", handlerTypeLoggable)
+	tab.startAnimation("pkgC", "deploying", Msg.Info, "")
+	defer tab.stopAnimation("pkgC")
+
+	// Tracked update moves pkgA to the end of tabContents; group order must not change
+	h.sendMessageWithHandler("compiled", Msg.Success, tab, "pkgA", "pkgA", "", handlerTypeLoggable)
+
+	groups := tab.handlerGroups(tab.contentsSnapshot())
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+
+	want := []struct {
+		name   string
+		status groupStatus
+	}{
+		{"pkgA", groupStatusOK},
+		{"pkgB", groupStatusError},
+		{"pkgC", groupStatusRunning},
+	}
+	for i, w := range want {
+		if groups[i].name != w.name {
+			t.Errorf("group %d: expected %q, got %q", i, w.name, groups[i].name)
+		}
+		if groups[i].status != w.status {
+			t.Errorf("group %q: expected status %d, got %d", w.name, w.status, groups[i].status)
+		}
+	}
+}
+
+func TestHandlerGroups_KeyboardToggleAndCollapse(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 80, 20
+	tab := h.NewTabSection("BUILD", "Monorepo build").(*tabSection)
+	h.activeTab = tab.Index
+
+	h.sendMessageWithHandler("first package ok", Msg.Info, tab, "pkgA", "pkgA", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("second package broken", Msg.Error, tab, "pkgB", "pkgB", "", handlerTypeLoggable)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if !tab.groupedView {
+		t.Fatal("Ctrl+G should enable grouped view")
+	}
+	if !strings.Contains(h.ContentView(), "[ ERR ]") {
+		t.Error("grouped view should show an error badge for pkgB")
+	}
+
+	// Select pkgB and collapse it
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyDown})
+	if tab.selectedGroup != 1 {
+		t.Fatalf("expected selected group 1, got %d", tab.selectedGroup)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeySpace})
+	if !tab.collapsedGroups["pkgB"] {
+		t.Fatal("Space should collapse the selected group")
+	}
+
+	view := h.ContentView()
+	if strings.Contains(view, "second package broken") {
+		t.Error("collapsed group should hide its messages")
+	}
+	if !strings.Contains(view, "first package ok") {
+		t.Error("expanded group should still show its messages")
+	}
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if tab.groupedView {
+		t.Error("second Ctrl+G should return to the flat view")
+	}
+}
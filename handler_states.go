@@ -0,0 +1,91 @@
+package devtui
+
+import "slices"
+
+// Local handler snapshot: the fields of the local tabs as StateEntry values,
+// the wire format client mode rebuilds remote fields from (see headless.go).
+
+// handlerStates returns a StateEntry per field and writing-only handler of the
+// local tabs, in tab and field order. Built-in tabs and remote fields are skipped.
+func (h *DevTUI) handlerStates() []StateEntry {
+	shortcuts := h.shortcutRegistry.Entries()
+	var entries []StateEntry
+	for _, ts := range h.TabSections {
+		if ts.remote || ts.isOverview || ts.Title == "SHORTCUTS" {
+			continue
+		}
+		for _, f := range ts.FieldHandlers {
+			if f.isRemote || f.handler == nil {
+				continue
+			}
+			entries = append(entries, localStateEntry(ts, f.handler, shortcuts))
+		}
+		ts.mu.RLock()
+		writers := slices.Clone(ts.writingHandlers)
+		ts.mu.RUnlock()
+		for _, w := range writers {
+			name := w.Name()
+			if !slices.ContainsFunc(entries, func(e StateEntry) bool { return e.TabTitle == ts.Title && e.HandlerName == name }) {
+				entries = append(entries, localStateEntry(ts, w, shortcuts))
+			}
+		}
+	}
+	return entries
+}
+
+// localStateEntry describes a handler of tab ts. Shortcuts lists its registered
+// keys as {value passed to Change: description}.
+func localStateEntry(ts *tabSection, a *anyHandler, shortcuts []*ShortcutEntry) StateEntry {
+	entry := StateEntry{
+		TabTitle:     ts.Title,
+		HandlerName:  a.Name(),
+		HandlerColor: a.handlerColor,
+		HandlerType:  int(a.handlerType),
+		Shortcut:     a.Name(),
+	}
+	if a.handlerType != handlerTypeLoggable {
+		entry.Label = a.Label()
+		entry.Value = a.Value()
+	}
+	for _, s := range shortcuts {
+		if !s.Remote && s.TabIndex == ts.Index && s.HandlerName == entry.HandlerName {
+			entry.Shortcuts = append(entry.Shortcuts, map[string]string{s.Value: s.Description})
+		}
+	}
+	return entry
+}
+
+// runLocalAction calls Change(value) on the local field whose handler is named
+// key, or Change(shortcut value) on the field of the local shortcut key.
+// Reports false when neither matches or the handler panics.
+func (h *DevTUI) runLocalAction(key, value string) (ran bool) {
+	// Panic recovery for safety, like field.handleEnter
+	defer func() {
+		if r := recover(); r != nil && h.Logger != nil {
+			h.Logger("Handler panic:", r)
+		}
+	}()
+
+	for _, ts := range h.TabSections {
+		if ts.remote || ts.isOverview {
+			continue
+		}
+		for _, f := range ts.FieldHandlers {
+			if !f.isRemote && f.handler != nil && f.handler.Name() == key {
+				f.handler.Change(value)
+				return true
+			}
+		}
+	}
+
+	entry, ok := h.shortcutRegistry.Get(key)
+	if !ok || entry.Remote || entry.TabIndex >= len(h.TabSections) {
+		return false
+	}
+	fields := h.TabSections[entry.TabIndex].FieldHandlers
+	if entry.FieldIndex >= len(fields) || fields[entry.FieldIndex].handler == nil {
+		return false
+	}
+	fields[entry.FieldIndex].handler.Change(entry.Value)
+	return true
+}
@@ -0,0 +1,341 @@
+package devtui
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Headless mode (TuiConfig.Headless): the handlers run without a terminal and
+// are served on TuiConfig.ServeAddr to client mode devtui instances:
+//
+//	GET  /logs  SSE stream of tabContentDTO lines ("id:" per line, replayed after
+//	            Last-Event-ID) and "event: state" snapshots when a field changes
+//	POST /mcp   JSON-RPC "tinywasm/state" ([]StateEntry) and "tinywasm/action" (ActionArgs)
+//
+// TuiConfig.APIKey, when set, is required as a Bearer token. A loop goroutine
+// stands in for the UI goroutine: it drains the message channel and runs the
+// actions, so handlers are not changed concurrently.
+
+// defaultServeAddr is the headless listen address when TuiConfig.ServeAddr is
+// empty: loopback only, other machines need an explicit address.
+const defaultServeAddr = "127.0.0.1:3030"
+
+// maxHeadlessReplay is how many log lines a reconnecting client can catch up on.
+const maxHeadlessReplay = 500
+
+// headlessStatePoll is how often the loop looks for field changes no log line announced.
+var headlessStatePoll = time.Second
+
+// headlessEvent is one SSE message.
+type headlessEvent struct {
+	id    string // "" for state snapshots, which are not replayed
+	event string // "" = log line
+	data  []byte
+}
+
+// headlessAction is a tinywasm/action call waiting for the loop goroutine.
+type headlessAction struct {
+	args ActionArgs
+	done chan bool
+}
+
+// headlessServer is the HTTP side of headless mode.
+type headlessServer struct {
+	tui     *DevTUI
+	server  *http.Server
+	ctx     context.Context
+	cancel  context.CancelFunc
+	actions chan headlessAction
+	running atomic.Bool // loop goroutine started
+
+	mu          sync.Mutex
+	seq         int
+	replay      []headlessEvent             // last log lines, oldest first
+	subscribers map[chan headlessEvent]bool // connected /logs streams
+	state       []byte                      // JSON of the last published snapshot
+}
+
+func newHeadlessServer(h *DevTUI) *headlessServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &headlessServer{
+		tui:         h,
+		ctx:         ctx,
+		cancel:      cancel,
+		actions:     make(chan headlessAction),
+		subscribers: make(map[chan headlessEvent]bool),
+	}
+	addr := h.ServeAddr
+	if addr == "" {
+		addr = defaultServeAddr
+	}
+	s.server = &http.Server{Addr: addr, Handler: s.handler()}
+	return s
+}
+
+// serve runs the server until close. When the server cannot listen, the loop
+// is stopped too.
+func (s *headlessServer) serve() error {
+	s.start()
+	if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
+		s.cancel()
+		return err
+	}
+	return nil
+}
+
+// start publishes the first snapshot and starts the loop goroutine.
+func (s *headlessServer) start() {
+	s.publishState()
+	s.running.Store(true)
+	go s.loop()
+}
+
+// close stops the loop, the server and the open streams.
+func (s *headlessServer) close() {
+	s.tui.isShuttingDown.Store(true)
+	s.cancel()
+	s.server.Close()
+}
+
+// handler routes /logs and /mcp, checking the API key.
+func (s *headlessServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/logs", s.handleLogs)
+	mux.HandleFunc("/mcp", s.handleMCP)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := s.tui.APIKey; key != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+key)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// loop is the headless UI goroutine.
+func (s *headlessServer) loop() {
+	ticker := time.NewTicker(headlessStatePoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case content := <-s.tui.tabContentsChan:
+			s.publishLog(content)
+			s.publishState()
+		case action := <-s.actions:
+			ok := s.tui.runLocalAction(action.args.Key, action.args.Value)
+			s.publishState() // before answering: a state fetch after the answer sees the change
+			action.done <- ok
+		case <-ticker.C:
+			s.publishState()
+		}
+	}
+}
+
+// publishLog sends a log line to the streams and keeps it for replay.
+// LogOpen animation frames are skipped: clients animate progress lines themselves.
+func (s *headlessServer) publishLog(c tabContent) {
+	if c.animationFrame {
+		return
+	}
+	data, err := json.Marshal(headlessDTO(c))
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	event := headlessEvent{id: Sprintf("%d", s.seq), data: data}
+	s.replay = append(s.replay, event)
+	if len(s.replay) > maxHeadlessReplay {
+		s.replay = s.replay[len(s.replay)-maxHeadlessReplay:]
+	}
+	s.broadcast(event)
+}
+
+// publishState sends an "event: state" snapshot when the fields changed.
+func (s *headlessServer) publishState() {
+	data, err := json.Marshal(s.tui.handlerStates())
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bytes.Equal(data, s.state) {
+		return
+	}
+	s.state = data
+	s.broadcast(headlessEvent{event: "state", data: data})
+}
+
+// stateSnapshot returns the JSON of the last published snapshot.
+func (s *headlessServer) stateSnapshot() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.state)
+}
+
+// broadcast queues an event on every stream; a stream too slow to keep up
+// misses it and catches up on its next reconnect. Requires s.mu.
+func (s *headlessServer) broadcast(event headlessEvent) {
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// subscribe registers a stream and returns the log lines sent after lastID
+// (all kept lines when lastID is unknown).
+func (s *headlessServer) subscribe(lastID string) (chan headlessEvent, []headlessEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan headlessEvent, 256)
+	s.subscribers[ch] = true
+	backlog := s.replay
+	if i := slices.IndexFunc(s.replay, func(e headlessEvent) bool { return e.id == lastID }); i >= 0 {
+		backlog = s.replay[i+1:]
+	}
+	return ch, slices.Clone(backlog)
+}
+
+func (s *headlessServer) unsubscribe(ch chan headlessEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, ch)
+}
+
+// handleLogs serves the SSE stream.
+func (s *headlessServer) handleLogs(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch, backlog := s.subscribe(r.Header.Get("Last-Event-ID"))
+	defer s.unsubscribe(ch)
+	for _, event := range backlog {
+		writeHeadlessEvent(w, event)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.ctx.Done():
+			return
+		case event := <-ch:
+			writeHeadlessEvent(w, event)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeHeadlessEvent(w http.ResponseWriter, event headlessEvent) {
+	var b bytes.Buffer
+	if event.id != "" {
+		b.WriteString("id: " + event.id + "\n")
+	}
+	if event.event != "" {
+		b.WriteString("event: " + event.event + "\n")
+	}
+	b.WriteString("data: ")
+	b.Write(event.data)
+	b.WriteString("\n\n")
+	w.Write(b.Bytes())
+}
+
+// handleMCP answers the JSON-RPC calls of client mode.
+func (s *headlessServer) handleMCP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+		return
+	}
+
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "tinywasm/state":
+		resp["result"] = json.RawMessage(s.stateSnapshot())
+	case "tinywasm/action":
+		var args ActionArgs
+		if err := json.Unmarshal(req.Params, &args); err != nil {
+			resp["error"] = map[string]any{"code": -32602, "message": "invalid params"}
+			break
+		}
+		ok, err := s.dispatch(r.Context(), args)
+		if err != nil {
+			resp["error"] = map[string]any{"code": -32000, "message": err.Error()}
+			break
+		}
+		resp["result"] = ok
+	default:
+		resp["error"] = map[string]any{"code": -32601, "message": "method not found"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// dispatch runs an action on the loop goroutine and returns whether a field took it.
+func (s *headlessServer) dispatch(ctx context.Context, args ActionArgs) (bool, error) {
+	action := headlessAction{args: args, done: make(chan bool, 1)}
+	select {
+	case s.actions <- action:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-s.ctx.Done():
+		return false, s.ctx.Err()
+	}
+	return <-action.done, nil
+}
+
+// headlessDTO converts a message to its wire format. The lines of a running
+// LogOpen operation are sent as progress lines and its timed LogClose line as
+// the complete one, so clients animate the operation like a local LogOpen.
+func headlessDTO(c tabContent) tabContentDTO {
+	hType := c.handlerType
+	if hType == handlerTypeDisplay {
+		hType = handlerTypeLoggable // handler type 0 is the clients' state refresh signal
+	}
+	dto := tabContentDTO{
+		Id:             c.Id,
+		Timestamp:      c.Timestamp,
+		Content:        c.Content,
+		Type:           c.Type,
+		HandlerName:    c.RawHandlerName,
+		RawHandlerName: c.RawHandlerName,
+		HandlerColor:   c.handlerColor,
+		HandlerType:    hType,
+		OperationID:    c.operationID,
+		IsProgress:     c.isProgress || (c.openedAt != "" && c.duration == 0),
+		IsComplete:     c.isComplete || c.duration > 0,
+		DurationMs:     c.duration.Milliseconds(),
+	}
+	if c.tabSection != nil {
+		dto.TabTitle = c.tabSection.Title
+	}
+	return dto
+}
@@ -0,0 +1,181 @@
+//go:build !wasm
+
+package devtui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or fails the test after 2s.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for !cond() {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s", what)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestHeadless_ClientAttaches(t *testing.T) {
+	server := NewTUI(&TuiConfig{Headless: true, APIKey: "secret"})
+	port := NewTestEditableHandler("Port", "8080")
+	server.AddHandler(port, "", server.NewTabSection("BUILD", ""))
+	server.headless.start()
+	defer server.headless.close()
+	daemon := httptest.NewServer(server.headless.handler())
+	defer daemon.Close()
+
+	config := &TuiConfig{ClientMode: true, ClientURL: daemon.URL + "/logs", APIKey: "secret"}
+	client := NewTUI(config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.sseWg.Add(1)
+	go client.startSSEClient(config.ClientURL, ctx)
+
+	// Reads the client tabs through runOnUI, like the SSE goroutine changes them
+	remoteField := func() (f *field) {
+		client.runOnUI(func() {
+			if section := client.tabByTitle("BUILD"); section != nil && len(section.FieldHandlers) == 1 {
+				f = section.FieldHandlers[0]
+			}
+		})
+		return f
+	}
+	waitFor(t, "the remote Port field", func() bool { return remoteField() != nil })
+	f := remoteField()
+	if f.Value() != "8080" || f.handler.Name() != "PortHandler" {
+		t.Fatalf("unexpected remote field %s=%q", f.handler.Name(), f.Value())
+	}
+
+	f.handler.Change("9090")
+	waitLabel(t, f, "✔")
+	if port.Value() != "9090" {
+		t.Errorf("the action should change the headless handler, got %q", port.Value())
+	}
+	waitFor(t, "the Saved log line", func() bool {
+		for _, c := range f.parentTab.contentsSnapshot() {
+			if strings.Contains(c.Content, "Saved: 9090") {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestHeadless_AuthAndReplay(t *testing.T) {
+	tui := NewTUI(&TuiConfig{Headless: true, APIKey: "secret"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	daemon := httptest.NewServer(tui.headless.handler())
+	defer daemon.Close()
+
+	resp, err := http.Get(daemon.URL + "/logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("a request without the API key should be refused, got %s", resp.Status)
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		tui.headless.publishLog(tabContent{Id: id, Content: id, tabSection: section})
+	}
+	ch, backlog := tui.headless.subscribe("2")
+	defer tui.headless.unsubscribe(ch)
+	if len(backlog) != 1 || backlog[0].id != "3" || !strings.Contains(string(backlog[0].data), `"tab_title":"BUILD"`) {
+		t.Errorf("expected only the line after Last-Event-ID 2, got %+v", backlog)
+	}
+	if _, all := tui.headless.subscribe("unknown"); len(all) != 3 {
+		t.Errorf("an unknown Last-Event-ID should replay every kept line, got %d", len(all))
+	}
+}
+
+func TestHeadless_GetHandlerStates(t *testing.T) {
+	tui := NewTUI(&TuiConfig{Headless: true})
+	port := NewTestEditableHandler("Port", "8080")
+	tui.AddHandler(port, "", tui.NewTabSection("BUILD", ""))
+	tui.headless.start()
+	defer tui.headless.close()
+
+	if !tui.DispatchAction("PortHandler", "9090") {
+		t.Fatal("the action should run on the loop")
+	}
+	if got := string(tui.GetHandlerStates()); got != string(tui.headless.stateSnapshot()) || !strings.Contains(got, `"value":"9090"`) {
+		t.Errorf("a running headless server should return its published snapshot, got %s", got)
+	}
+}
+
+func TestHeadless_OperationLines(t *testing.T) {
+	tui := NewTUI(&TuiConfig{Headless: true})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	ch, _ := tui.headless.subscribe("")
+	defer tui.headless.unsubscribe(ch)
+
+	tui.headless.publishLog(tabContent{Id: "1", Content: "Deploying", openedAt: "1", tabSection: section})
+	tui.headless.publishLog(tabContent{Id: "1", Content: "Deploying . .", openedAt: "1", animationFrame: true, tabSection: section})
+	tui.headless.publishLog(tabContent{Id: "1", Content: "Deployed (2s)", openedAt: "1", duration: 2 * time.Second, tabSection: section})
+
+	var lines []string
+	for len(ch) > 0 {
+		lines = append(lines, string((<-ch).data))
+	}
+	if len(lines) != 2 {
+		t.Fatalf("animation frames should not be sent, got %d lines", len(lines))
+	}
+	if !strings.Contains(lines[0], `"is_progress":true`) {
+		t.Errorf("the LogOpen line should be a progress line, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"is_complete":true`) || strings.Contains(lines[1], `"is_progress":true`) {
+		t.Errorf("the LogClose line should complete the operation, got %s", lines[1])
+	}
+}
+
+func TestHeadless_ServeAddr(t *testing.T) {
+	if addr := NewTUI(&TuiConfig{Headless: true}).headless.server.Addr; addr != defaultServeAddr {
+		t.Errorf("an empty ServeAddr should listen on loopback, got %q", addr)
+	}
+
+	taken := httptest.NewServer(http.NotFoundHandler())
+	defer taken.Close()
+	tui := NewTUI(&TuiConfig{Headless: true, ServeAddr: taken.Listener.Addr().String()})
+	if err := tui.headless.serve(); err == nil {
+		t.Fatal("serving on a taken address should fail")
+	}
+	select {
+	case <-tui.headless.ctx.Done():
+	default:
+		t.Error("a failed serve should stop the loop")
+	}
+}
+
+func TestHeadless_StartUntilShutdown(t *testing.T) {
+	tui := NewTUI(&TuiConfig{Headless: true, ServeAddr: "127.0.0.1:0", Overview: true})
+	tui.NewTabSection("BUILD", "")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go tui.Start(&wg)
+	tui.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start should return after Shutdown in headless mode")
+	}
+	if got := tabTitles(tui); got != "BUILD" {
+		t.Errorf("headless mode should not add the built-in tabs, got %s", got)
+	}
+}
@@ -0,0 +1,78 @@
+package devtui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+	"github.com/tinywasm/fmt/lang"
+)
+
+// Help overlay ("?" from any tab): the SHORTCUTS help page drawn over the
+// content area, scrolled page by page, without leaving the current tab.
+
+// helpOverlay holds the state of the open help overlay (DevTUI.help, nil when closed).
+type helpOverlay struct {
+	lines  []string
+	offset int // first visible line
+}
+
+// openHelp renders the help page and shows it over the content area.
+func (h *DevTUI) openHelp() {
+	guide := &shortcutsInteractiveHandler{appName: h.AppName, lang: lang.OutLang(), tui: h}
+	h.help = &helpOverlay{lines: Convert(guide.generateHelpContent()).Split("\n")}
+}
+
+// closeHelp hides the help overlay.
+func (h *DevTUI) closeHelp() {
+	h.help = nil
+}
+
+// helpPageSize is the number of help lines visible at once (title excluded).
+func (h *DevTUI) helpPageSize() int {
+	return max(h.viewport.Height-1, 1)
+}
+
+// handleHelpKeyboard scrolls or closes the help overlay.
+func (h *DevTUI) handleHelpKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
+	o := h.help
+	last := max(len(o.lines)-h.helpPageSize(), 0)
+	switch action := h.keymap.action(keyStroke(msg)); {
+	case action == ActionHelp || msg.Type == tea.KeyEsc:
+		h.closeHelp()
+	case action == ActionQuit:
+		h.closeHelp()
+		return h.handleNormalModeKeyboard(msg)
+	case action == ActionScrollUp:
+		o.offset = max(o.offset-1, 0)
+	case action == ActionScrollDown:
+		o.offset = min(o.offset+1, last)
+	case action == ActionPageUp:
+		o.offset = max(o.offset-h.helpPageSize(), 0)
+	case action == ActionPageDown, action == ActionToggleGroup:
+		o.offset = min(o.offset+h.helpPageSize(), last)
+	}
+	return false, nil
+}
+
+// helpView renders the visible page of the help overlay with a page indicator.
+func (h *DevTUI) helpView() string {
+	o := h.help
+	size := h.helpPageSize()
+	end := min(o.offset+size, len(o.lines))
+
+	pages := (len(o.lines) + size - 1) / size
+	page := o.offset/size + 1
+	if end == len(o.lines) {
+		page = pages
+	}
+	title := h.headerTitleStyle.Render(Sprintf("Help %d/%d", page, max(pages, 1))) + " " +
+		h.timeStyle.Render("PgUp/PgDown scroll · ? or Esc close")
+
+	lines := []string{title}
+	for _, l := range o.lines[o.offset:end] {
+		lines = append(lines, h.textContentStyle.Render(l))
+	}
+	for len(lines) < size+1 {
+		lines = append(lines, "")
+	}
+	return Convert(lines).Join("\n").String()
+}
@@ -0,0 +1,82 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShortcutHelp_GroupedInRegistrationOrder(t *testing.T) {
+	h := DefaultTUIForTest()
+	build := h.NewTabSection("BUILD", "Build")
+	deploy := h.NewTabSection("DEPLOY", "Deploy")
+	h.AddHandler(&testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", ""),
+		shortcuts:           []map[string]string{{"z": "zip"}, {"a": "assemble"}, {"m": "minify"}},
+	}, "", build)
+	newRemoteField(StateEntry{
+		TabTitle: "DEPLOY", HandlerName: "WASM", HandlerType: HandlerTypeEdit,
+		Shortcuts: []map[string]string{{"L": "Large"}},
+	}, nil, deploy.(*tabSection), h)
+
+	guide := &shortcutsInteractiveHandler{tui: h}
+	want := strings.Join([]string{
+		"  BUILD",
+		"    ModeHandler",
+		"      • z - zip",
+		"      • a - assemble",
+		"      • m - minify",
+		"  DEPLOY",
+		"    WASM (remote)",
+		"      • L - L",
+	}, "\n")
+	for i := 0; i < 5; i++ { // stable between renders
+		if got := strings.Join(guide.registeredShortcutLines(), "\n"); got != want {
+			t.Fatalf("render %d:\nexpected:\n%s\ngot:\n%s", i, want, got)
+		}
+	}
+}
+
+func TestShortcutHelp_KeysFromTheKeymap(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.keymap.Actions[ActionPin] = []string{"ctrl+b"}
+
+	help := (&shortcutsInteractiveHandler{tui: h}).generateHelpContent()
+	for _, want := range []string{"ctrl+b           - pin", "alt+t            - timestamps", "alt+s            - timings", "ctrl+r           - reconnect"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help should list %q, got:\n%s", want, help)
+		}
+	}
+	if strings.Contains(help, "alt+p") {
+		t.Error("help should show the keymap keys, not the defaults")
+	}
+}
+
+func TestHelpOverlay_OpenScrollClose(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 80, 5
+	logs := h.NewTabSection("LOGS", "Logs")
+	h.activeTab = logs.(*tabSection).Index
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if h.help == nil {
+		t.Fatal("? should open the help overlay")
+	}
+	if !strings.Contains(h.helpView(), "Help 1/") {
+		t.Errorf("overlay should show its page, got %q", h.helpView())
+	}
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyPgDown})
+	if h.help.offset != h.helpPageSize() {
+		t.Errorf("PgDown should move one page, offset=%d", h.help.offset)
+	}
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEsc})
+	if h.help != nil {
+		t.Error("Esc should close the help overlay")
+	}
+	if h.activeTab != logs.(*tabSection).Index {
+		t.Error("the overlay must not change the active tab")
+	}
+}
@@ -69,8 +69,31 @@ func NewTUI(c *TuiConfig) *DevTUI {
 		id:               id,                    // Set the ID here
 		shortcutRegistry: newShortcutRegistry(), // NEW: Initialize shortcut registry
 		testMode:         c.TestMode,
+		mouseEnabled:     c.Mouse,
+		timestampMode:    c.TimestampMode,
+		startedAt:        time.Now(),
+		showTimings:      c.TimingStats,
 		sseCancel:        noopCancel,
 	}
+	if c.Headless {
+		tui.headless = newHeadlessServer(tui)
+	}
+	if c.ClientMode {
+		for _, e := range validEndpoints(c.clientEndpoints(), c.Logger) {
+			tui.daemons = append(tui.daemons, newRemoteDaemon(tui, e))
+		}
+	}
+
+	keymap, err := loadKeymap(c)
+	if err != nil && c.Logger != nil {
+		c.Logger("Keymap error:", err)
+	}
+	tui.keymap = keymap
+
+	tui.shortcutRegistry.policy = c.ShortcutConflictPolicy
+	tui.shortcutRegistry.logger = c.Logger
+	reserveBuiltinKeys(tui.shortcutRegistry, &tui.keymap)
+	reserveTabJumpKeys(tui.shortcutRegistry)
 
 	// FIXED: Removed manual content sending to prevent duplication
 	// HandlerDisplay automatically shows Content() when field is selected
@@ -87,20 +110,33 @@ func (h *DevTUI) rebuildTeaProgram() {
 		// Use a reader that returns EOF immediately to exit Bubble Tea loop
 		options = append(options, tea.WithInput(strings.NewReader("")), tea.WithoutRenderer())
 	} else {
+		if h.clipboardOut == nil {
+			h.clipboardOut = &terminalOutput{File: os.Stdout}
+		}
 		options = append(options, tea.WithAltScreen())
+		if out, ok := h.clipboardOut.(*terminalOutput); ok {
+			options = append(options, tea.WithOutput(out)) // OSC 52 writes share the renderer's output
+		}
+		if h.Mouse {
+			options = append(options, tea.WithMouseCellMotion())
+		}
 	}
 
 	h.tea = tea.NewProgram(h, options...)
+	h.uiQuit = make(chan struct{})
 }
 
 // Init initializes the terminal UI application.
 func (h *DevTUI) Init() tea.Cmd {
+	h.startUI()
 	// Start SSE client here (sections must be registered before replay messages arrive)
-	if h.ClientMode && h.ClientURL != "" {
+	if h.ClientMode && len(h.daemons) > 0 {
 		ctx, cancel := context.WithCancel(context.Background())
-		h.sseCancel = cancel // set before goroutine starts — no race
-		h.sseWg.Add(1)
-		go h.startSSEClient(h.ClientURL, ctx)
+		h.sseCancel = cancel // set before goroutines start — no race
+		for _, d := range h.daemons {
+			h.sseWg.Add(1)
+			go d.startSSEClient(d.url, ctx)
+		}
 	}
 	return tea.Batch(
 		h.listenToMessages(),
@@ -142,16 +178,22 @@ func (h *DevTUI) Start(args ...any) {
 		defer wg.Done()
 	}
 
-	// Add SHORTCUTS tab last, after all user tabs are registered
-	// Only add if it doesn't already exist (idempotency)
+	// Add OVERVIEW and SHORTCUTS tabs last, after all user tabs are registered
+	// Only add if they don't already exist (idempotency)
+	overviewExists := false
 	shortcutsExists := false
 	for _, tab := range h.TabSections {
-		if tab.Title == "SHORTCUTS" {
+		switch tab.Title {
+		case overviewTabTitle:
+			overviewExists = true
+		case "SHORTCUTS":
 			shortcutsExists = true
-			break
 		}
 	}
-	if !shortcutsExists {
+	if h.Overview && !overviewExists && h.headless == nil {
+		createOverviewTab(h)
+	}
+	if !shortcutsExists && h.headless == nil {
 		createShortcutsTab(h)
 	}
 
@@ -159,7 +201,12 @@ func (h *DevTUI) Start(args ...any) {
 	h.checkAndTriggerInteractiveContent()
 	h.notifyTabActive(h.activeTab)
 
-	if _, err := h.tea.Run(); err != nil {
+	if h.headless != nil {
+		// No terminal: serve the handlers until Shutdown (see headless.go)
+		if err := h.headless.serve(); err != nil && h.Logger != nil {
+			h.Logger("Headless server error:", err)
+		}
+	} else if _, err := h.runProgram(); err != nil {
 		os.Stdout.WriteString(fmt.Sprintf("Error running DevTUI: %v\n", err))
 		if !h.isTestMode() {
 			os.Stdout.WriteString("\nPress any key to exit...\n")
@@ -193,6 +240,12 @@ func (h *DevTUI) Start(args ...any) {
 
 // TestOnlyRun is for testing purposes only.
 func (h *DevTUI) TestOnlyRun() (tea.Model, error) {
+	return h.runProgram()
+}
+
+// runProgram runs the tea program until it quits.
+func (h *DevTUI) runProgram() (tea.Model, error) {
+	defer h.stopUI()
 	return h.tea.Run()
 }
 
@@ -203,6 +256,10 @@ type shutdownMsg struct{}
 // Shutdown signals the TUI to stop gracefully.
 // Safe to call from any goroutine (OS signal handlers, external callers).
 func (h *DevTUI) Shutdown() {
+	if h.headless != nil {
+		h.headless.close()
+		return
+	}
 	if h.tea != nil {
 		go h.tea.Send(shutdownMsg{})
 	}
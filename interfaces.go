@@ -38,7 +38,14 @@ type HandlerInteractive interface {
 // ShortcutProvider defines the optional interface for handlers that provide global shortcuts.
 // HandlerEdit implementations can implement this interface to enable global shortcut keys.
 type ShortcutProvider interface {
-	Shortcuts() []map[string]string // Returns ordered list of single-entry maps with shortcut->description, preserving registration order
+	Shortcuts() []map[string]string // Returns ordered list of single-entry maps with shortcut->description, preserving registration order. Keys: "c", "ctrl+b", "alt+d", "F5" or chords like "g d"
+}
+
+// ScopedShortcutProvider is the scope-aware form of ShortcutProvider: each shortcut
+// can be global, limited to the handler's tab or to its selected field, so the same
+// key can do different things per tab. Takes precedence over Shortcuts() when both exist.
+type ScopedShortcutProvider interface {
+	ScopedShortcuts() []ScopedShortcut // Ordered shortcuts with key spec, description and scope
 }
 
 // Cancelable defines the optional interface for handlers that want to be notified when the user cancels.
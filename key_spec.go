@@ -0,0 +1,174 @@
+package devtui
+
+import (
+	"slices"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+// Key specs accepted by ShortcutRegistry.Register / ShortcutProvider.Shortcuts():
+//
+//	"c", "C", "?"      single printable character (case sensitive)
+//	"ctrl+b", "alt+d"  modifiers: ctrl+, alt+, shift+ (combinable: "ctrl+shift+up")
+//	"F5", "pgdown"     named keys (case insensitive)
+//	"g d"              chord: strokes separated by spaces, pressed one after another
+//
+// Specs are normalized to the names bubbletea's tea.KeyMsg.String() reports
+// (lowercase modifiers and names, "space" for the space bar) so registry keys
+// can be compared with keyStroke(msg) directly.
+
+// namedKeys are the key names accepted in a key spec (without modifiers).
+var namedKeys = map[string]bool{
+	"space": true, "enter": true, "tab": true, "esc": true, "backspace": true,
+	"delete": true, "insert": true, "home": true, "end": true, "pgup": true, "pgdown": true,
+	"up": true, "down": true, "left": true, "right": true,
+}
+
+func init() {
+	for i := 1; i <= 20; i++ {
+		namedKeys[Sprintf("f%d", i)] = true
+	}
+}
+
+// parseKeySpec validates a key spec and returns its normalized strokes.
+func parseKeySpec(spec string) ([]string, error) {
+	fields := Convert(spec).Split()
+	var strokes []string
+	for _, f := range fields {
+		stroke, err := normalizeStroke(f)
+		if err != nil {
+			return nil, Err(Sprintf("invalid shortcut '%s': %s", spec, err.Error()))
+		}
+		strokes = append(strokes, stroke)
+	}
+	if len(strokes) == 0 {
+		return nil, Err(Sprintf("invalid shortcut '%s': empty key", spec))
+	}
+	return strokes, nil
+}
+
+// normalizeStroke validates one stroke ("ctrl+b", "F5", "g") and returns its normalized form.
+func normalizeStroke(stroke string) (string, error) {
+	// A single character is taken literally (including "+")
+	if runes := []rune(stroke); len(runes) == 1 {
+		if !unicode.IsPrint(runes[0]) {
+			return "", Err("non printable key")
+		}
+		return stroke, nil
+	}
+
+	parts := Convert(stroke).Split("+")
+	key := parts[len(parts)-1]
+	mods := parts[:len(parts)-1]
+	if key == "" { // "ctrl++" style: the key itself is "+"
+		key = "+"
+		mods = mods[:len(mods)-1]
+	}
+
+	var ctrl, alt, shift bool
+	for _, m := range mods {
+		switch Convert(m).ToLower().String() {
+		case "ctrl":
+			ctrl = true
+		case "alt":
+			alt = true
+		case "shift":
+			shift = true
+		default:
+			return "", Err(Sprintf("unknown modifier '%s'", m))
+		}
+	}
+
+	if len([]rune(key)) > 1 {
+		key = Convert(key).ToLower().String()
+		if !namedKeys[key] {
+			return "", Err(Sprintf("unknown key '%s'", key))
+		}
+	} else if !unicode.IsPrint([]rune(key)[0]) {
+		return "", Err("non printable key")
+	}
+
+	// bubbletea only reports ctrl+letter, shift+navigation and ctrl+shift+navigation
+	if ctrl && len([]rune(key)) == 1 {
+		key = Convert(key).ToLower().String()
+	}
+	if shift && !isNavigationKey(key) && key != "tab" {
+		return "", Err("shift+ only combines with tab and navigation keys")
+	}
+
+	out := ""
+	if alt {
+		out += "alt+"
+	}
+	if ctrl {
+		out += "ctrl+"
+	}
+	if shift {
+		out += "shift+"
+	}
+	return out + key, nil
+}
+
+// isNavigationKey reports whether key is an arrow/home/end key.
+func isNavigationKey(key string) bool {
+	switch key {
+	case "up", "down", "left", "right", "home", "end":
+		return true
+	}
+	return false
+}
+
+// keyStroke returns the normalized stroke of a key press ("" for pasted text).
+func keyStroke(msg tea.KeyMsg) string {
+	if msg.Paste {
+		return ""
+	}
+	if msg.Type == tea.KeySpace {
+		if msg.Alt {
+			return "alt+space"
+		}
+		return "space"
+	}
+	return msg.String()
+}
+
+// reserveBuiltinKeys reserves the keys the keymap binds to built-in actions.
+func reserveBuiltinKeys(sr *ShortcutRegistry, km *Keymap) {
+	for _, action := range keyActions {
+		for _, k := range km.keys(action) {
+			sr.Reserve(k, string(action))
+		}
+	}
+	sr.Reserve("esc", "cancel")
+}
+
+// handleShortcutKey feeds a key press to the shortcut registry, tracking chords
+// in h.pendingChord. consumed is false when the key is not part of any shortcut.
+func (h *DevTUI) handleShortcutKey(msg tea.KeyMsg) (continueParsing bool, cmd tea.Cmd, consumed bool) {
+	stroke := keyStroke(msg)
+	if stroke == "" {
+		return true, nil, false
+	}
+	seq := append(slices.Clone(h.pendingChord), stroke)
+	field := -1
+	if h.activeTab < len(h.TabSections) {
+		field = h.TabSections[h.activeTab].IndexActiveEditField
+	}
+	entry, pending := h.shortcutRegistry.Lookup(seq, h.activeTab, field)
+	switch {
+	case entry != nil:
+		h.pendingChord = nil
+		continueParsing, cmd = h.executeShortcut(entry)
+		return continueParsing, cmd, true
+	case pending:
+		h.pendingChord = seq
+		return false, nil, true
+	case len(h.pendingChord) > 0:
+		// Chord broken (or cancelled with Esc): drop it and swallow the key
+		h.pendingChord = nil
+		return false, nil, true
+	}
+	return true, nil, false
+}
@@ -0,0 +1,102 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	valid := map[string]string{
+		"c":             "c",
+		"C":             "C",
+		"ctrl+b":        "ctrl+b",
+		"Ctrl+B":        "ctrl+b",
+		"alt+d":         "alt+d",
+		"F5":            "f5",
+		"ctrl+shift+up": "ctrl+shift+up",
+		"g d":           "g d",
+		"ctrl+x  s":     "ctrl+x s",
+	}
+	for spec, want := range valid {
+		strokes, err := parseKeySpec(spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", spec, err)
+			continue
+		}
+		if got := strings.Join(strokes, " "); got != want {
+			t.Errorf("%q: expected %q, got %q", spec, want, got)
+		}
+	}
+
+	for _, spec := range []string{"", "hyper+x", "ctrl+banana", "shift+a", "F42"} {
+		if _, err := parseKeySpec(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestShortcutRegistry_RejectsAmbiguousAndReserved(t *testing.T) {
+	h := DefaultTUIForTest()
+	sr := h.shortcutRegistry
+
+	if err := sr.TryRegister("g d", &ShortcutEntry{HandlerName: "Deployer"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sr.TryRegister("g", &ShortcutEntry{HandlerName: "Git"}); err == nil {
+		t.Error("\"g\" should be rejected: it is the prefix of chord \"g d\"")
+	}
+	if err := sr.TryRegister("ctrl+p", &ShortcutEntry{HandlerName: "Printer"}); err == nil {
+		t.Error("ctrl+p is the command palette and should be reserved")
+	}
+	if err := sr.TryRegister("alt+d", &ShortcutEntry{HandlerName: "Deployer"}); err != nil {
+		t.Errorf("alt+d, the documented example, should stay free for handlers: %v", err)
+	}
+	if err := sr.TryRegister("space x", &ShortcutEntry{HandlerName: "Leader"}); err == nil {
+		t.Error("a chord starting with a reserved key should be rejected")
+	}
+	if err := sr.TryRegister("bad+key", &ShortcutEntry{HandlerName: "Broken"}); err == nil || !strings.Contains(err.Error(), "bad+key") {
+		t.Errorf("invalid spec should fail with a clear error, got %v", err)
+	}
+}
+
+func TestShortcuts_ModifierAndChordExecution(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 80, 10
+	h.NewTabSection("LOGS", "Logs")
+	build := h.NewTabSection("BUILD", "Build")
+	mode := &testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", "none"),
+		shortcuts:           []map[string]string{{"ctrl+b": "build"}, {"g d": "deploy"}, {"F5": "refresh"}},
+	}
+	h.AddHandler(mode, "", build)
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if got := mode.Value(); got != "ctrl+b" {
+		t.Errorf("ctrl+b should run the shortcut, value is %q", got)
+	}
+
+	h.activeTab = 0
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if len(h.pendingChord) != 1 {
+		t.Fatalf("\"g\" should start a chord, pending=%v", h.pendingChord)
+	}
+	if !strings.Contains(h.renderScrollInfo(), "g") {
+		t.Error("footer should show the pending chord")
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if got := mode.Value(); got != "g d" {
+		t.Errorf("\"g d\" should run the chord shortcut, value is %q", got)
+	}
+	if h.pendingChord != nil || h.activeTab != build.(*tabSection).Index {
+		t.Errorf("chord should clear and navigate to BUILD (pending=%v tab=%d)", h.pendingChord, h.activeTab)
+	}
+
+	// A broken chord is dropped without side effects
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEsc})
+	if h.pendingChord != nil {
+		t.Error("Esc should cancel the pending chord")
+	}
+}
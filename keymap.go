@@ -0,0 +1,256 @@
+package devtui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	. "github.com/tinywasm/fmt"
+)
+
+// KeyAction names a built-in normal-mode action that can be bound to keys.
+type KeyAction string
+
+const (
+	ActionQuit        KeyAction = "quit"         // shut down the TUI
+	ActionNextTab     KeyAction = "next_tab"     // activate the next tab
+	ActionPrevTab     KeyAction = "prev_tab"     // activate the previous tab
+	ActionNextField   KeyAction = "next_field"   // select the next field
+	ActionPrevField   KeyAction = "prev_field"   // select the previous field
+	ActionScrollUp    KeyAction = "scroll_up"    // scroll one line up (grouped view: previous header)
+	ActionScrollDown  KeyAction = "scroll_down"  // scroll one line down (grouped view: next header)
+	ActionPageUp      KeyAction = "page_up"      // scroll one page up
+	ActionPageDown    KeyAction = "page_down"    // scroll one page down
+	ActionEdit        KeyAction = "edit"         // edit the field or run its action
+	ActionPalette     KeyAction = "palette"      // open/close the command palette
+	ActionGroups      KeyAction = "groups"       // toggle the grouped handler view
+	ActionToggleGroup KeyAction = "toggle_group" // expand/collapse the selected handler group
+	ActionHelp        KeyAction = "help"         // open/close the shortcut help overlay
+	ActionMouse       KeyAction = "mouse"        // toggle mouse capture (TuiConfig.Mouse)
+	ActionCopyMode    KeyAction = "copy_mode"    // select messages to copy to the clipboard (OSC 52)
+	ActionCopyField   KeyAction = "copy_field"   // copy the selected field value to the clipboard
+	ActionPin         KeyAction = "pin"          // pin/unpin the newest (or copy mode selected) message
+	ActionUnpinAll    KeyAction = "unpin_all"    // remove every pin of the tab
+	ActionNextPin     KeyAction = "next_pin"     // scroll the log to the next pinned message
+	ActionPrevPin     KeyAction = "prev_pin"     // scroll the log to the previous pinned message
+	ActionTimestamps  KeyAction = "timestamps"   // cycle the timestamp display mode
+	ActionTimings     KeyAction = "timings"      // show/hide the operation timing stats
+	ActionReconnect   KeyAction = "reconnect"    // client mode: reconnect to the daemon now
+)
+
+// keyActions lists every action in display order.
+var keyActions = []KeyAction{
+	ActionQuit, ActionNextTab, ActionPrevTab, ActionNextField, ActionPrevField,
+	ActionScrollUp, ActionScrollDown, ActionPageUp, ActionPageDown, ActionEdit,
+	ActionPalette, ActionGroups, ActionToggleGroup, ActionHelp, ActionMouse,
+	ActionCopyMode, ActionCopyField, ActionPin, ActionUnpinAll, ActionNextPin, ActionPrevPin,
+	ActionTimestamps, ActionTimings, ActionReconnect,
+}
+
+// Keymap binds built-in actions to keys and remaps handler shortcuts.
+// The JSON form is the keymap file (TuiConfig.KeymapFile, by default
+// <user config dir>/<AppName>/keymap.json):
+//
+//	{
+//	  "actions":   {"next_tab": ["tab", "ctrl+n"], "scroll_down": ["down", "j"]},
+//	  "shortcuts": {"DatabaseHandler:t": "ctrl+t", "DatabaseHandler:b": ""}
+//	}
+//
+// Action keys are single key specs (see key_spec.go). Shortcuts are keyed by
+// "HandlerName:key" and map to the new key spec ("" disables the shortcut).
+// Editing text in a field keeps its fixed keys (Enter, Esc, arrows, Backspace).
+type Keymap struct {
+	Actions   map[KeyAction][]string `json:"actions"`
+	Shortcuts map[string]string      `json:"shortcuts,omitempty"`
+
+	byKey      map[string]KeyAction // normalized key -> action
+	overridden map[KeyAction]bool   // actions set by the keymap file
+}
+
+// DefaultKeymap returns the built-in key bindings.
+func DefaultKeymap() Keymap {
+	km := Keymap{Actions: map[KeyAction][]string{
+		ActionQuit:        {"ctrl+c"},
+		ActionNextTab:     {"tab"},
+		ActionPrevTab:     {"shift+tab"},
+		ActionNextField:   {"right"},
+		ActionPrevField:   {"left"},
+		ActionScrollUp:    {"up"},
+		ActionScrollDown:  {"down"},
+		ActionPageUp:      {"pgup"},
+		ActionPageDown:    {"pgdown"},
+		ActionEdit:        {"enter"},
+		ActionPalette:     {"ctrl+p"},
+		ActionGroups:      {"ctrl+g"},
+		ActionToggleGroup: {"space"},
+		ActionHelp:        {"?"},
+		ActionMouse:       {"ctrl+o"},
+		ActionCopyMode:    {"ctrl+y"},
+		ActionCopyField:   {"alt+c"},
+		ActionPin:         {"alt+p"},
+		ActionUnpinAll:    {"alt+u"},
+		ActionNextPin:     {"alt+j"},
+		ActionPrevPin:     {"alt+k"},
+		ActionTimestamps:  {"alt+t"},
+		ActionTimings:     {"alt+s"},
+		ActionReconnect:   {"ctrl+r"},
+	}}
+	km.index()
+	return km
+}
+
+// index rebuilds the key -> action lookup.
+func (km *Keymap) index() {
+	km.byKey = make(map[string]KeyAction)
+	for _, action := range keyActions {
+		for _, k := range km.Actions[action] {
+			km.byKey[normalizeKey(k)] = action
+		}
+	}
+}
+
+// action returns the action bound to a normalized key stroke ("" if none).
+func (km *Keymap) action(stroke string) KeyAction {
+	return km.byKey[stroke]
+}
+
+// keys returns the keys bound to action, for display.
+func (km *Keymap) keys(action KeyAction) []string {
+	return km.Actions[action]
+}
+
+// shortcutKey returns the key a handler shortcut is bound to after remapping.
+// ok is false when the keymap disables it.
+func (km *Keymap) shortcutKey(handlerName, key string) (string, bool) {
+	remapped, exists := km.Shortcuts[handlerName+":"+key]
+	if !exists {
+		return key, true
+	}
+	return remapped, remapped != ""
+}
+
+// merge applies the overrides of a keymap file on top of km.
+// Invalid entries are skipped and reported in the returned error; valid ones still apply.
+func (km *Keymap) merge(override Keymap) error {
+	var problems []string
+	for action, keys := range override.Actions {
+		if !slices.Contains(keyActions, action) {
+			problems = append(problems, Sprintf("unknown action '%s'", string(action)))
+			continue
+		}
+		var valid []string
+		for _, k := range keys {
+			strokes, err := parseKeySpec(k)
+			switch {
+			case err != nil:
+				problems = append(problems, err.Error())
+			case len(strokes) > 1:
+				problems = append(problems, Sprintf("action '%s': chords are not supported ('%s')", string(action), k))
+			default:
+				valid = append(valid, strokes[0])
+			}
+		}
+		if len(valid) > 0 {
+			km.Actions[action] = valid
+			if km.overridden == nil {
+				km.overridden = make(map[KeyAction]bool)
+			}
+			km.overridden[action] = true
+		}
+	}
+	// Two actions on one key: the overridden action keeps it, the other loses it
+	for action := range km.overridden {
+		for _, k := range km.Actions[action] {
+			for _, other := range keyActions {
+				if other != action && !km.overridden[other] && slices.Contains(km.Actions[other], k) {
+					km.Actions[other] = slices.DeleteFunc(slices.Clone(km.Actions[other]), func(s string) bool { return s == k })
+				}
+			}
+		}
+	}
+	if len(override.Shortcuts) > 0 {
+		km.Shortcuts = make(map[string]string, len(override.Shortcuts))
+		for id, k := range override.Shortcuts {
+			if k != "" {
+				if _, err := parseKeySpec(k); err != nil {
+					problems = append(problems, Sprintf("shortcut '%s': %s", id, err.Error()))
+					continue
+				}
+			}
+			km.Shortcuts[id] = k
+		}
+	}
+	km.index()
+	if len(problems) > 0 {
+		return Err("keymap: " + Convert(problems).Join("; ").String())
+	}
+	return nil
+}
+
+// keymapPath returns the keymap file to load ("" = none).
+func keymapPath(c *TuiConfig) string {
+	if c.KeymapFile != "" {
+		return c.KeymapFile
+	}
+	if c.TestMode || c.AppName == "" {
+		return "" // tests never pick up the developer's own keymap
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, c.AppName, "keymap.json")
+}
+
+// loadKeymap returns the default keymap with the user keymap file applied.
+// A missing file is not an error.
+func loadKeymap(c *TuiConfig) (Keymap, error) {
+	km := DefaultKeymap()
+	path := keymapPath(c)
+	if path == "" {
+		return km, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return km, nil
+		}
+		return km, err
+	}
+	var override Keymap
+	if err := json.Unmarshal(data, &override); err != nil {
+		return km, Err("keymap " + path + ": " + err.Error())
+	}
+	return km, km.merge(override)
+}
+
+// applyViewportKeys binds the viewport line/page scrolling to the keymap.
+// Only actions overridden by the keymap file replace the viewport defaults
+// (which also scroll with k/j, b/f...).
+func (km *Keymap) applyViewportKeys(vp *viewport.Model) {
+	bindings := map[KeyAction]func(keys ...string){
+		ActionScrollUp:   vp.KeyMap.Up.SetKeys,
+		ActionScrollDown: vp.KeyMap.Down.SetKeys,
+		ActionPageUp:     vp.KeyMap.PageUp.SetKeys,
+		ActionPageDown:   vp.KeyMap.PageDown.SetKeys,
+	}
+	for action, set := range bindings {
+		if km.overridden[action] {
+			keys := make([]string, len(km.Actions[action]))
+			for i, k := range km.Actions[action] {
+				keys[i] = bindingKey(k)
+			}
+			set(keys...)
+		}
+	}
+}
+
+// bindingKey converts a normalized key to the name bubbles key bindings compare with.
+func bindingKey(k string) string {
+	if k == "space" {
+		return " "
+	}
+	return k
+}
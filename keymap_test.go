@@ -0,0 +1,93 @@
+package devtui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeKeymap(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestKeymap_RemapsBuiltinActions(t *testing.T) {
+	path := writeKeymap(t, `{"actions": {"next_tab": ["ctrl+n"], "scroll_down": ["down", "j"]}}`)
+	h := NewTUI(&TuiConfig{KeymapFile: path, Logger: func(...any) {}})
+	h.NewTabSection("A", "")
+	h.NewTabSection("B", "")
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if h.activeTab != 1 {
+		t.Errorf("ctrl+n should move to the next tab, active tab is %d", h.activeTab)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyTab})
+	if h.activeTab != 1 {
+		t.Errorf("tab is no longer bound to next_tab, active tab is %d", h.activeTab)
+	}
+
+	vp := viewport.New(80, 10)
+	h.keymap.applyViewportKeys(&vp)
+	if got := vp.KeyMap.Down.Keys(); strings.Join(got, ",") != "down,j" {
+		t.Errorf("viewport scroll down keys should follow the keymap, got %v", got)
+	}
+	if got := vp.KeyMap.Up.Keys(); strings.Join(got, ",") != "up,k" {
+		t.Errorf("actions not in the keymap file keep the viewport defaults, got %v", got)
+	}
+	if _, reserved := h.shortcutRegistry.IsReserved("ctrl+n"); !reserved {
+		t.Error("keys bound to built-in actions must be reserved")
+	}
+}
+
+func TestKeymap_RemapsHandlerShortcuts(t *testing.T) {
+	path := writeKeymap(t, `{"shortcuts": {"ModeHandler:c": "ctrl+k", "ModeHandler:d": ""}}`)
+	h := NewTUI(&TuiConfig{KeymapFile: path, Logger: func(...any) {}})
+	tab := h.NewTabSection("BUILD", "")
+	mode := &testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", ""),
+		shortcuts:           []map[string]string{{"c": "coding mode"}, {"d": "debug mode"}},
+	}
+	h.AddHandler(mode, "", tab)
+
+	if _, exists := h.shortcutRegistry.Get("c"); exists {
+		t.Error("\"c\" was remapped and should not be registered")
+	}
+	if _, exists := h.shortcutRegistry.Get("d"); exists {
+		t.Error("\"d\" was disabled and should not be registered")
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if got := mode.Value(); got != "c" {
+		t.Errorf("ctrl+k should run the original \"c\" shortcut with value \"c\", got %q", got)
+	}
+}
+
+func TestKeymap_InvalidEntriesAreReported(t *testing.T) {
+	path := writeKeymap(t, `{"actions": {"teleport": ["x"], "prev_tab": ["hyper+q"], "quit": ["ctrl+q"]}}`)
+	var logged []string
+	h := NewTUI(&TuiConfig{KeymapFile: path, Logger: func(m ...any) {
+		for _, v := range m {
+			if err, ok := v.(error); ok {
+				logged = append(logged, err.Error())
+			}
+		}
+	}})
+
+	joined := strings.Join(logged, "\n")
+	if !strings.Contains(joined, "teleport") || !strings.Contains(joined, "hyper+q") {
+		t.Errorf("unknown actions and bad keys should be reported, got %q", joined)
+	}
+	if h.keymap.action("ctrl+q") != ActionQuit {
+		t.Error("valid entries still apply when others are invalid")
+	}
+	if h.keymap.action("shift+tab") != ActionPrevTab {
+		t.Error("an action with only invalid keys keeps its defaults")
+	}
+}
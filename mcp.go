@@ -1,6 +1,8 @@
 package devtui
 
 import (
+	"encoding/json"
+
 	"github.com/tinywasm/mcp"
 )
 
@@ -10,11 +12,38 @@ func (d *DevTUI) GetMCPTools() []mcp.Tool {
 	return nil
 }
 
-// GetHandlerStates returns nil — DevTUI is a client, not a state server.
-func (d *DevTUI) GetHandlerStates() []byte { return nil }
+// GetHandlerStates returns the local handlers as a JSON []StateEntry, the
+// snapshot client mode and headless mode use (remote fields are not included).
+// The handlers are read on the UI goroutine; a running headless server returns
+// the snapshot its loop last published.
+func (d *DevTUI) GetHandlerStates() []byte {
+	if s := d.headless; s != nil && s.running.Load() {
+		return s.stateSnapshot()
+	}
+	var entries []StateEntry
+	d.runOnUI(func() { entries = d.handlerStates() })
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil
+	}
+	return data
+}
 
-// DispatchAction returns false — actions are forwarded to the daemon, not dispatched locally.
-func (d *DevTUI) DispatchAction(_, _ string) bool { return false }
+// DispatchAction runs Change(value) on the local field whose handler is named
+// key, or the shortcut registered as key (with its own value). It runs on the
+// UI goroutine while the TUI is running (the headless loop in headless mode),
+// waiting for it, and reports false when nothing matches. It is meant for
+// callers outside the TUI (MCP, other goroutines): a handler's Change already
+// runs on that goroutine and must not call it.
+func (d *DevTUI) DispatchAction(key, value string) bool {
+	if s := d.headless; s != nil && s.running.Load() {
+		ok, err := s.dispatch(s.ctx, ActionArgs{Key: key, Value: value})
+		return err == nil && ok
+	}
+	var ok bool
+	d.runOnUI(func() { ok = d.runLocalAction(key, value) })
+	return ok
+}
 
 // Name implements Loggable interface for MCP integration
 func (d *DevTUI) Name() string {
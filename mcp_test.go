@@ -1,6 +1,7 @@
 package devtui
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -8,3 +9,72 @@ func TestMcpPlaceholder(t *testing.T) {
 	// MCP tools were moved to app daemon.
 	// This file is kept to maintain the package test structure if needed.
 }
+
+func TestGetHandlerStates(t *testing.T) {
+	h := DefaultTUIForTest()
+	build := h.NewTabSection("BUILD", "")
+	h.AddHandler(&testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", "dev"),
+		shortcuts:           []map[string]string{{"ctrl+b": "build"}},
+	}, "#ff0000", build)
+	h.AddHandler(&testLoggable{name: "Compiler"}, "", build)
+	createShortcutsTab(h)
+
+	var entries []StateEntry
+	if err := json.Unmarshal(h.GetHandlerStates(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the field and the writer of BUILD only, got %+v", entries)
+	}
+	mode := entries[0]
+	if mode.TabTitle != "BUILD" || mode.HandlerName != "ModeHandler" || mode.HandlerType != HandlerTypeEdit ||
+		mode.Value != "dev" || mode.HandlerColor != "#ff0000" || mode.Shortcut != "ModeHandler" {
+		t.Errorf("unexpected field entry %+v", mode)
+	}
+	if len(mode.Shortcuts) != 1 || mode.Shortcuts[0]["ctrl+b"] != "build" {
+		t.Errorf("expected the registered shortcut, got %v", mode.Shortcuts)
+	}
+	if entries[1].HandlerName != "Compiler" || entries[1].HandlerType != HandlerTypeLoggable {
+		t.Errorf("unexpected writer entry %+v", entries[1])
+	}
+}
+
+func TestDispatchAction(t *testing.T) {
+	h := DefaultTUIForTest()
+	build := h.NewTabSection("BUILD", "")
+	mode := &testModeHandler{
+		TestEditableHandler: NewTestEditableHandler("Mode", "dev"),
+		shortcuts:           []map[string]string{{"ctrl+b": "build"}},
+	}
+	h.AddHandler(mode, "", build)
+
+	if !h.DispatchAction("ModeHandler", "prod") || mode.Value() != "prod" {
+		t.Errorf("the handler name should route to its field, value is %q", mode.Value())
+	}
+	if !h.DispatchAction("ctrl+b", "") || mode.Value() != "ctrl+b" {
+		t.Errorf("a shortcut key should run the shortcut, value is %q", mode.Value())
+	}
+	if h.DispatchAction("missing", "x") {
+		t.Error("an unknown key should report false")
+	}
+
+	var logged []any
+	h.Logger = func(messages ...any) { logged = append(logged, messages[0]) }
+	h.AddHandler(&panicEditHandler{NewTestEditableHandler("Crash", "")}, "", build)
+	if h.DispatchAction("CrashHandler", "x") || len(logged) == 0 || logged[len(logged)-1] != "Handler panic:" {
+		t.Errorf("a panicking handler should be recovered, logged and report false, logged %v", logged)
+	}
+
+	// While running, the call is carried to the UI goroutine and waited for
+	stop := startTestProgram(t, h)
+	defer stop()
+	if !h.DispatchAction("ModeHandler", "test") || mode.Value() != "test" {
+		t.Errorf("the action should run on the UI goroutine, value is %q", mode.Value())
+	}
+}
+
+// panicEditHandler is an edit handler whose Change panics.
+type panicEditHandler struct{ *TestEditableHandler }
+
+func (h *panicEditHandler) Change(string) { panic("boom") }
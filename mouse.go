@@ -0,0 +1,88 @@
+package devtui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Mouse mode (TuiConfig.Mouse): clicks on the header tab strip / pagination
+// switch tabs, clicks on the footer pagination cycle fields, clicks on a content
+// line expand its history (on an OVERVIEW row: jump to its handler), and the
+// wheel scrolls the viewport. Capture can be toggled (keymap action "mouse",
+// Ctrl+O) to use the terminal text selection.
+
+// appendLineOwner records id as the owner of the next height rendered lines.
+func appendLineOwner(owners []string, id string, height int) []string {
+	for range max(height, 1) {
+		owners = append(owners, id)
+	}
+	return owners
+}
+
+// toggleMouse switches mouse capture on/off. Returns the command that tells the
+// terminal to start or stop reporting mouse events (nil unless TuiConfig.Mouse).
+func (h *DevTUI) toggleMouse() tea.Cmd {
+	if !h.Mouse {
+		return nil
+	}
+	h.mouseEnabled = !h.mouseEnabled
+	h.viewport.MouseWheelEnabled = h.mouseEnabled
+	if h.mouseEnabled {
+		return tea.EnableMouseCellMotion
+	}
+	return tea.DisableMouse
+}
+
+// handleMouse handles a left click. Wheel events are scrolled by the viewport.
+func (h *DevTUI) handleMouse(msg tea.MouseMsg) {
+	if !h.mouseEnabled || msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return
+	}
+	if len(h.TabSections) == 0 || h.editModeActivated || h.palette != nil || h.help != nil {
+		return
+	}
+
+	headerHeight := lipgloss.Height(h.headerView())
+	paginationWidth := PaginationColumnWidth + 2 // pagination style has 1 cell of padding per side
+	switch {
+	case h.TabBar && msg.Y == 1:
+		h.jumpToTab(h.tabBarHit(msg.X))
+
+	case msg.Y == 0 && msg.X >= h.viewport.Width-paginationWidth:
+		h.navigateTo((h.activeTab+1)%len(h.TabSections), -1)
+		h.updateViewport()
+		h.checkAndTriggerInteractiveContent()
+
+	case msg.Y == headerHeight+h.viewport.Height && msg.X < paginationWidth:
+		tab := h.TabSections[h.activeTab]
+		if total := len(tab.FieldHandlers); total > 0 {
+			tab.IndexActiveEditField = (tab.IndexActiveEditField + 1) % total
+			h.updateViewport()
+			h.checkAndTriggerInteractiveContent()
+		}
+
+	case msg.Y >= headerHeight && msg.Y < headerHeight+h.viewport.Height:
+		h.clickContentLine(h.viewport.YOffset + msg.Y - headerHeight)
+	}
+}
+
+// clickContentLine expands/collapses the history of the message rendered at line,
+// or jumps to the handler of the OVERVIEW row rendered there.
+func (h *DevTUI) clickContentLine(line int) {
+	if h.TabSections[h.activeTab].isOverview {
+		if row := line - 1; row >= 0 && row < len(h.overviewRows()) { // line 0 is the table header
+			h.overviewSelected = row
+			h.jumpToOverviewRow(row)
+		}
+		return
+	}
+	if line < 0 || line >= len(h.contentLineIds) || h.contentLineIds[line] == "" {
+		return
+	}
+	if h.TabSections[h.activeTab].toggleHistory(h.contentLineIds[line]) {
+		// Keep the clicked line in place instead of jumping to the bottom
+		offset := h.viewport.YOffset
+		h.viewport.SetContent(h.ContentView())
+		h.viewport.SetYOffset(offset)
+	}
+}
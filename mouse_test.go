@@ -0,0 +1,122 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+func newMouseTUI(t *testing.T) *DevTUI {
+	t.Helper()
+	h := NewTUI(&TuiConfig{Mouse: true, TabBar: true, Logger: func(...any) {}})
+	h.viewport.Width, h.viewport.Height = 100, 10
+	return h
+}
+
+func click(h *DevTUI, x, y int) {
+	h.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+func TestMouse_ClickTabStripAndFooterPagination(t *testing.T) {
+	h := newMouseTUI(t)
+	h.NewTabSection("BUILD", "")
+	config := h.NewTabSection("CONFIG", "").(*tabSection)
+	h.AddHandler(NewTestEditableHandler("Host", "localhost"), "", config)
+	h.AddHandler(NewTestEditableHandler("Port", "8080"), "", config)
+
+	// Second label of the tab strip (line 1 of the header)
+	x := lipgloss.Width(h.tabBarLabels()[0]) + 2
+	click(h, x, 1)
+	if h.activeTab != config.Index {
+		t.Fatalf("clicking CONFIG in the tab strip should activate it, active tab is %d", h.activeTab)
+	}
+
+	footerY := lipgloss.Height(h.headerView()) + h.viewport.Height
+	click(h, 1, footerY)
+	if config.IndexActiveEditField != 1 {
+		t.Errorf("clicking the footer pagination should select the next field, got %d", config.IndexActiveEditField)
+	}
+}
+
+func TestMouse_ClickLineExpandsHistory(t *testing.T) {
+	h := newMouseTUI(t)
+	tab := h.NewTabSection("BUILD", "").(*tabSection)
+
+	h.sendMessageWithHandler("compiling", Msg.Info, tab, "Compiler", "Compiler", "", handlerTypeLoggable)
+	h.sendAnimationFrame("compiling .", Msg.Info, tab, "Compiler", "")
+	h.sendMessageWithHandler("compiled ok", Msg.Success, tab, "Compiler", "Compiler", "", handlerTypeLoggable)
+
+	id := tab.contentsSnapshot()[0].Id
+	history, _ := tab.historyOf(id)
+	if len(history) != 1 || history[0].Content != "compiling" {
+		t.Fatalf("history should hold only the real previous message, got %+v", history)
+	}
+
+	h.updateViewport()
+	click(h, 5, lipgloss.Height(h.headerView())) // first content line
+	view := h.ContentView()
+	if !strings.Contains(view, "compiling") || !strings.Contains(view, "compiled ok") {
+		t.Errorf("clicked line should show its history, got:\n%s", view)
+	}
+
+	click(h, 5, lipgloss.Height(h.headerView()))
+	if strings.Contains(h.ContentView(), "compiling") {
+		t.Error("second click should collapse the history")
+	}
+}
+
+func TestContentHistory_PrunedWithTrimmedLines(t *testing.T) {
+	h := DefaultTUIForTest()
+	tab := h.NewTabSection("BUILD", "").(*tabSection)
+	h.sendMessageWithHandler("compiling", Msg.Info, tab, "Compiler", "Compiler", "", handlerTypeLoggable)
+	h.sendMessageWithHandler("compiled ok", Msg.Success, tab, "Compiler", "Compiler", "", handlerTypeLoggable)
+	id := tab.contentsSnapshot()[0].Id
+	tab.toggleHistory(id)
+
+	for i := range maxTabContents {
+		h.sendMessageWithHandler(Sprintf("line %d", i), Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	}
+	if history, expanded := tab.historyOf(id); len(history) != 0 || expanded {
+		t.Errorf("a trimmed line should drop its history, got %d versions (expanded %v)", len(history), expanded)
+	}
+}
+
+func TestMouse_ClickOverviewRowJumps(t *testing.T) {
+	h := newMouseTUI(t)
+	h.NewTabSection("LOGS", "")
+	config := h.NewTabSection("CONFIG", "").(*tabSection)
+	h.AddHandler(NewTestEditableHandler("Host", "localhost"), "", config)
+	h.AddHandler(NewTestEditableHandler("Port", "8080"), "", config)
+	createOverviewTab(h)
+	h.activeTab = len(h.TabSections) - 1
+	h.updateViewport()
+
+	click(h, 5, lipgloss.Height(h.headerView())+2) // header row, Host, Port
+	if h.activeTab != config.Index || config.IndexActiveEditField != 1 {
+		t.Errorf("clicking the Port row should jump to it, got tab %d field %d", h.activeTab, config.IndexActiveEditField)
+	}
+}
+
+func TestMouse_ToggleCapture(t *testing.T) {
+	h := newMouseTUI(t)
+	h.NewTabSection("LOGS", "")
+
+	_, cmd := h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if h.mouseEnabled || cmd == nil {
+		t.Fatal("Ctrl+O should release the mouse and return the terminal command")
+	}
+	click(h, 1, lipgloss.Height(h.headerView())+h.viewport.Height)
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !h.mouseEnabled {
+		t.Error("second Ctrl+O should capture the mouse again")
+	}
+
+	off := DefaultTUIForTest()
+	off.NewTabSection("LOGS", "")
+	if _, cmd := off.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlO}); cmd != nil || off.mouseEnabled {
+		t.Error("without TuiConfig.Mouse the toggle does nothing")
+	}
+}
@@ -0,0 +1,110 @@
+package devtui
+
+import (
+	"slices"
+	"time"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Operation timing: a LogOpen ... LogClose pair of a handler is an operation.
+// At LogClose the elapsed time since LogOpen is appended to the close line
+// ("Deployment complete (4.2s)"), stored on the line (tabContent.duration, kept in
+// its history and sent as duration_ms to SSE clients) and added to per-handler
+// stats shown by the "timings" keymap action (Alt+S) or TuiConfig.TimingStats.
+
+// maxTimedOperations bounds the durations kept per handler for the stats.
+const maxTimedOperations = 100
+
+// OperationTiming summarises the LogOpen/LogClose durations of one handler.
+type OperationTiming struct {
+	Tab     string        // tab title
+	Handler string        // handler name
+	Count   int           // operations timed (the last maxTimedOperations at most)
+	Last    time.Duration // duration of the last operation
+	Average time.Duration
+	P95     time.Duration // 95th percentile
+}
+
+// operationElapsed returns the time since the LogOpen of handlerName's running operation.
+func (ts *tabSection) operationElapsed(handlerName string) (time.Duration, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	start, ok := timestampTime(ts.operationStarts[handlerName])
+	if !ok {
+		return 0, false
+	}
+	return time.Since(start), true
+}
+
+// recordOperation adds the duration of handlerName's closed operation to the
+// handler stats (the close line carries it from sendTimedMessage).
+func (ts *tabSection) recordOperation(handlerName string, d time.Duration) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.operationDurations == nil {
+		ts.operationDurations = make(map[string][]time.Duration)
+	}
+	if !slices.Contains(ts.timedHandlers, handlerName) {
+		ts.timedHandlers = append(ts.timedHandlers, handlerName)
+	}
+	durations := append(ts.operationDurations[handlerName], d)
+	if len(durations) > maxTimedOperations {
+		durations = durations[len(durations)-maxTimedOperations:]
+	}
+	ts.operationDurations[handlerName] = durations
+}
+
+// operationTimings returns the stats of the tab's handlers, in first-timed order.
+func (ts *tabSection) operationTimings() []OperationTiming {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	timings := make([]OperationTiming, 0, len(ts.timedHandlers))
+	for _, name := range ts.timedHandlers {
+		durations := ts.operationDurations[name]
+		timing := OperationTiming{Tab: ts.Title, Handler: name, Count: len(durations), Last: durations[len(durations)-1]}
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		timing.Average = total / time.Duration(len(durations))
+		sorted := slices.Clone(durations)
+		slices.Sort(sorted)
+		timing.P95 = sorted[(len(sorted)*95+99)/100-1]
+		timings = append(timings, timing)
+	}
+	return timings
+}
+
+// OperationTimings returns the LogOpen/LogClose duration stats of every handler,
+// tab by tab.
+func (h *DevTUI) OperationTimings() []OperationTiming {
+	var timings []OperationTiming
+	for _, section := range h.TabSections {
+		timings = append(timings, section.operationTimings()...)
+	}
+	return timings
+}
+
+// toggleTimings shows or hides the timing stats panel.
+func (h *DevTUI) toggleTimings() {
+	h.showTimings = !h.showTimings
+	h.updateViewport()
+}
+
+// timingLines renders the stats panel of a tab above the log (nil when hidden or empty).
+func (h *DevTUI) timingLines(ts *tabSection) []string {
+	if !h.showTimings {
+		return nil
+	}
+	timings := ts.operationTimings()
+	if len(timings) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(timings))
+	for _, t := range timings {
+		lines = append(lines, h.textContentStyle.Render(Sprintf("⏱ %s last %s  avg %s  p95 %s  (%d runs)",
+			padHandlerName(t.Handler, HandlerNameWidth), formatElapsed(t.Last), formatElapsed(t.Average), formatElapsed(t.P95), t.Count)))
+	}
+	return lines
+}
@@ -0,0 +1,70 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOperationTiming_CloseLineCarriesDuration(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 120, 10
+	tab := h.NewTabSection("DEPLOY", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+	deployer := &testLoggable{name: "Deployer"}
+	h.AddHandler(deployer, "", tab)
+
+	deployer.logFunc(LogOpen, "Deploying")
+	tab.operationStarts["Deployer"] = nanoStamp(time.Now().Add(-4200 * time.Millisecond))
+	deployer.logFunc(LogClose, "Deployment complete")
+
+	line := tab.contentsSnapshot()[0]
+	if !strings.Contains(line.Content, "Deployment complete (4.2s)") && !strings.Contains(line.Content, "Deployment complete (4.3s)") {
+		t.Errorf("close line should carry the duration, got %q", line.Content)
+	}
+	if line.duration < 4200*time.Millisecond || line.duration > 5*time.Second {
+		t.Errorf("duration should be stored on the line, got %s", line.duration)
+	}
+	var sent tabContent
+	for len(h.tabContentsChan) > 0 {
+		sent = <-h.tabContentsChan
+	}
+	if sent.duration != line.duration {
+		t.Errorf("the close line sent to the channel should carry the duration, got %s", sent.duration)
+	}
+
+	// A LogClose without LogOpen is not timed
+	deployer.logFunc(LogClose, "Nothing to close")
+	if got := tab.contentsSnapshot()[0].Content; strings.TrimSpace(got) != "Nothing to close" {
+		t.Errorf("untimed close line should be left as is, got %q", got)
+	}
+}
+
+func TestOperationTiming_Stats(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 120, 10
+	tab := h.NewTabSection("BUILD", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+	for i := 1; i <= 20; i++ {
+		tab.recordOperation("Compiler", time.Duration(i)*time.Second)
+	}
+
+	timings := h.OperationTimings()
+	if len(timings) != 1 {
+		t.Fatalf("expected stats for one handler, got %+v", timings)
+	}
+	got := timings[0]
+	if got.Tab != "BUILD" || got.Count != 20 || got.Last != 20*time.Second || got.Average != 10500*time.Millisecond || got.P95 != 19*time.Second {
+		t.Errorf("unexpected stats %+v", got)
+	}
+
+	if strings.Contains(h.ContentView(), "p95") {
+		t.Error("stats panel should be hidden by default")
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true})
+	if view := h.ContentView(); !strings.Contains(view, "avg 10.5s") || !strings.Contains(view, "p95 19.0s") {
+		t.Errorf("Alt+S should show the stats panel, got:\n%s", view)
+	}
+}
@@ -0,0 +1,228 @@
+package devtui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+// overviewTabTitle is the title of the built-in status dashboard tab.
+const overviewTabTitle = "OVERVIEW"
+
+// createOverviewTab registers the built-in OVERVIEW tab (TuiConfig.Overview).
+// It has no fields: ContentView renders the handler table directly.
+func createOverviewTab(tui *DevTUI) {
+	overview := tui.NewTabSection(overviewTabTitle, "Last state of every handler").(*tabSection)
+	overview.isOverview = true
+}
+
+// overviewRow is one handler line of the OVERVIEW table.
+type overviewRow struct {
+	tabIndex   int
+	fieldIndex int // -1 when the handler only logs (no field to select)
+	tabTitle   string
+	name       string
+	color      string
+	hType      handlerType
+	lastMsg    string
+	lastType   MessageType
+	lastUpdate string // tabContent.Timestamp of the last message ("" = never logged)
+	running    bool   // LogOpen animation in progress
+}
+
+// overviewRows collects every handler of every tab (except the built-in tabs):
+// fields first, then logging-only handlers, then names only seen in messages
+// (e.g. remote logs in client mode).
+func (h *DevTUI) overviewRows() []overviewRow {
+	var rows []overviewRow
+	for _, section := range h.TabSections {
+		if section.isOverview || section.Title == "SHORTCUTS" {
+			continue
+		}
+
+		contents := section.contentsSnapshot()
+		seen := make(map[string]bool)
+		add := func(name, color string, hType handlerType, fieldIndex int) {
+			if seen[name] {
+				return
+			}
+			seen[name] = true
+			row := overviewRow{
+				tabIndex:   section.Index,
+				fieldIndex: fieldIndex,
+				tabTitle:   section.Title,
+				name:       name,
+				color:      color,
+				hType:      hType,
+			}
+			for i := len(contents) - 1; i >= 0; i-- {
+				if contents[i].RawHandlerName == name {
+					row.lastMsg = contents[i].Content
+					row.lastType = contents[i].Type
+					row.lastUpdate = contents[i].Timestamp
+					if row.color == "" {
+						row.color = contents[i].handlerColor
+					}
+					break
+				}
+			}
+			section.mu.RLock()
+			row.running = section.animationStopChans[name] != nil
+			section.mu.RUnlock()
+			rows = append(rows, row)
+		}
+
+		for i, f := range section.FieldHandlers {
+			if f.handler != nil {
+				add(f.handler.Name(), f.handler.handlerColor, f.handler.handlerType, i)
+			}
+		}
+		section.mu.RLock()
+		writers := make([]*anyHandler, len(section.writingHandlers))
+		copy(writers, section.writingHandlers)
+		section.mu.RUnlock()
+		for _, w := range writers {
+			add(w.Name(), w.handlerColor, w.handlerType, -1)
+		}
+		for _, c := range contents {
+			if c.RawHandlerName != "" {
+				add(c.RawHandlerName, c.handlerColor, c.handlerType, -1)
+			}
+		}
+	}
+	return rows
+}
+
+// overviewContent renders the OVERVIEW table and anchors the selected row.
+func (h *DevTUI) overviewContent() string {
+	rows := h.overviewRows()
+	if len(rows) == 0 {
+		return h.textContentStyle.Render("No handlers registered yet")
+	}
+	if h.overviewSelected >= len(rows) {
+		h.overviewSelected = len(rows) - 1
+	}
+
+	header := "  " + padRight("TAB", 10) + " " + padRight("HANDLER", HandlerNameWidth) + " " +
+		padRight("COLOR", 8) + " " + padRight("TYPE", 11) + " " + padRight("STATUS", 7) + " " +
+		padRight("AGE", 4) + " LAST MESSAGE"
+	lines := []string{h.textContentStyle.Bold(true).Render(header)}
+
+	now := time.Now()
+	for i, r := range rows {
+		marker := "  "
+		if i == h.overviewSelected {
+			marker = "▶ "
+			h.contentAnchor = len(lines)
+		}
+
+		status := r.lastType.String()
+		if r.lastUpdate == "" {
+			status = "-"
+		}
+		if r.running {
+			status = "Running"
+		}
+
+		age := "-"
+		if t, ok := timestampTime(r.lastUpdate); ok {
+			age = formatAge(now.Sub(t))
+		}
+
+		color := r.color
+		if color == "" {
+			color = "-"
+		}
+
+		prefix := marker + padRight(Convert(r.tabTitle).Truncate(10, 0).String(), 10) + " "
+		rest := " " + padRight(color, 8) + " " + padRight(handlerTypeName(r.hType), 11) + " " +
+			h.applyMessageTypeStyle(padRight(status, 7), r.lastType) + " " + padRight(age, 4) + " "
+		used := lipgloss.Width(prefix) + HandlerNameWidth + lipgloss.Width(rest) + 2
+		lastMsg := Convert(r.lastMsg).Replace("\n", " ").String()
+		if width := h.viewport.Width - used; width > 0 {
+			lastMsg = Convert(lastMsg).Truncate(width, 0).String()
+		}
+
+		line := prefix + h.formatHandlerName(padHandlerName(r.name, HandlerNameWidth), r.color) + rest + lastMsg
+		if i == h.overviewSelected {
+			lines = append(lines, h.textContentStyle.Bold(true).Render(line))
+		} else {
+			lines = append(lines, h.textContentStyle.Render(line))
+		}
+	}
+	return Convert(lines).Join("\n").String()
+}
+
+// handleOverviewKeyboard handles row selection in the OVERVIEW tab.
+// Returns true when the key was consumed.
+func (h *DevTUI) handleOverviewKeyboard(action KeyAction) bool {
+	switch action {
+	case ActionScrollUp:
+		if h.overviewSelected > 0 {
+			h.overviewSelected--
+		}
+		h.updateViewport()
+		return true
+	case ActionScrollDown:
+		if h.overviewSelected < len(h.overviewRows())-1 {
+			h.overviewSelected++
+		}
+		h.updateViewport()
+		return true
+	case ActionEdit:
+		h.jumpToOverviewRow(h.overviewSelected)
+		return true
+	}
+	return false
+}
+
+// jumpToOverviewRow navigates to the tab (and field, if any) of an OVERVIEW row.
+func (h *DevTUI) jumpToOverviewRow(index int) {
+	rows := h.overviewRows()
+	if index < 0 || index >= len(rows) {
+		return
+	}
+	h.navigateTo(rows[index].tabIndex, rows[index].fieldIndex)
+	h.updateViewport()
+}
+
+// handlerTypeName returns a short human name for a handler type.
+func handlerTypeName(t handlerType) string {
+	switch t {
+	case handlerTypeDisplay:
+		return "display"
+	case handlerTypeEdit:
+		return "edit"
+	case handlerTypeExecution:
+		return "execution"
+	case handlerTypeInteractive:
+		return "interactive"
+	default:
+		return "log"
+	}
+}
+
+// formatAge renders a duration as a compact age: "5s", "3m", "2h", "4d".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return Sprintf("%dh", int(d.Hours()))
+	default:
+		return Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// padRight pads s with spaces up to width display cells.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + Convert(" ").Repeat(width-w).String()
+	}
+	return s
+}
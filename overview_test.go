@@ -0,0 +1,77 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+func TestOverview_RowsAcrossTabs(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 120, 20
+
+	build := h.NewTabSection("BUILD", "Compiler")
+	h.AddHandler(NewTestEditableHandler("Port", "8080"), "#3b82f6", build)
+	compiler := &testLoggable{name: "Compiler"}
+	h.AddHandler(compiler, "#ff0000", build)
+	compiler.logFunc("build failed: undefined symbol")
+
+	deploy := h.NewTabSection("DEPLOY", "Deploy")
+	deployer := &testLoggable{name: "Deployer"}
+	h.AddHandler(deployer, "", deploy)
+	deployer.logFunc(LogOpen, "Deploying")
+	defer deploy.(*tabSection).stopAnimation("Deployer")
+
+	createOverviewTab(h)
+
+	rows := h.overviewRows()
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows (Port, Compiler, Deployer), got %d", len(rows))
+	}
+	if rows[0].name != "PortHandler" || rows[0].fieldIndex != 0 {
+		t.Errorf("expected first row to be the PortHandler field, got %+v", rows[0])
+	}
+	if rows[1].name != "Compiler" || rows[1].lastType != Msg.Error || rows[1].fieldIndex != -1 {
+		t.Errorf("expected Compiler row with error status and no field, got %+v", rows[1])
+	}
+	if !rows[2].running {
+		t.Error("expected Deployer row to report a running animation")
+	}
+
+	h.activeTab = len(h.TabSections) - 1
+	view := h.ContentView()
+	for _, want := range []string{"HANDLER", "Compiler", "Running", "undefined symbol"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("overview view should contain %q", want)
+		}
+	}
+}
+
+func TestOverview_EnterJumpsToTabAndField(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 120, 20
+
+	h.NewTabSection("LOGS", "Logs")
+	config := h.NewTabSection("CONFIG", "Config")
+	h.AddHandler(NewTestEditableHandler("Host", "localhost"), "", config)
+	h.AddHandler(NewTestEditableHandler("Port", "8080"), "", config)
+
+	createOverviewTab(h)
+	h.activeTab = len(h.TabSections) - 1
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyDown})
+	if h.overviewSelected != 1 {
+		t.Fatalf("expected row 1 selected, got %d", h.overviewSelected)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEnter})
+
+	configTab := config.(*tabSection)
+	if h.activeTab != configTab.Index {
+		t.Errorf("expected jump to CONFIG tab (%d), got %d", configTab.Index, h.activeTab)
+	}
+	if configTab.IndexActiveEditField != 1 {
+		t.Errorf("expected Port field (1) selected, got %d", configTab.IndexActiveEditField)
+	}
+}
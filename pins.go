@@ -0,0 +1,141 @@
+package devtui
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+// Pins (bookmarks): messages marked with the "pin" keymap action (Alt+P) are
+// copied into tabSection.pins and rendered at the top of the tab, above the
+// scrolling log, like Display content. Being copies, they survive the 500
+// message truncation and later tracked-line updates of the same handler.
+// "next_pin"/"prev_pin" (Alt+J/Alt+K) scroll the log to the pinned message.
+// A pin is a version of a message: each version of a tracked line can be
+// pinned, and jumping to an older one expands the line history to show it.
+
+// pinKey identifies a message version, like the SSE dedupe key (see markSeen):
+// a tracked line keeps its Id across updates.
+func pinKey(tc tabContent) string {
+	return tc.Id + "|" + tc.Timestamp
+}
+
+// togglePin pins tc in the tab, or unpins it if that version is already pinned.
+func (ts *tabSection) togglePin(tc tabContent) (pinned bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	key := pinKey(tc)
+	if i := slices.IndexFunc(ts.pins, func(p tabContent) bool { return pinKey(p) == key }); i >= 0 {
+		ts.pins = slices.Delete(ts.pins, i, i+1)
+		if ts.pinFocus == key {
+			ts.pinFocus = ""
+		}
+		return false
+	}
+	ts.pins = append(ts.pins, tc)
+	return true
+}
+
+// clearPins removes every pin of the tab.
+func (ts *tabSection) clearPins() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.pins = nil
+	ts.pinFocus = ""
+}
+
+// pinsSnapshot returns the pins (in pin order) and the pinKey of the focused bookmark.
+func (ts *tabSection) pinsSnapshot() ([]tabContent, string) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return slices.Clone(ts.pins), ts.pinFocus
+}
+
+// pinSelection pins/unpins the copy mode selection, or the newest message
+// when copy mode is off.
+func (h *DevTUI) pinSelection() {
+	tab := h.TabSections[h.activeTab]
+	contents := tab.contentsSnapshot()
+	if len(contents) == 0 {
+		return
+	}
+	lo, hi := len(contents)-1, len(contents)-1
+	if h.copyMode != nil {
+		lo, hi = h.copyMode.bounds(contents)
+	}
+	for _, tc := range contents[lo : hi+1] {
+		tab.togglePin(tc)
+	}
+	h.updateViewport()
+}
+
+// jumpToPin moves the bookmark focus to the next (dir=1) or previous (dir=-1)
+// pin and scrolls the log to it, expanding the history of a tracked line when
+// the pinned version is an older one. The focus is cleared with Esc.
+func (h *DevTUI) jumpToPin(dir int) {
+	tab := h.TabSections[h.activeTab]
+	tab.mu.Lock()
+	if len(tab.pins) == 0 {
+		tab.mu.Unlock()
+		return
+	}
+	current := slices.IndexFunc(tab.pins, func(p tabContent) bool { return pinKey(p) == tab.pinFocus })
+	next := 0
+	switch {
+	case current >= 0:
+		next = (current + dir + len(tab.pins)) % len(tab.pins)
+	case dir < 0:
+		next = len(tab.pins) - 1
+	}
+	pin := tab.pins[next]
+	tab.pinFocus = pinKey(pin)
+	if slices.ContainsFunc(tab.history[pin.Id], func(v tabContent) bool { return pinKey(v) == tab.pinFocus }) {
+		if tab.expanded == nil {
+			tab.expanded = make(map[string]bool)
+		}
+		tab.expanded[pin.Id] = true
+	}
+	tab.mu.Unlock()
+	h.updateViewport()
+}
+
+// clearPinFocus stops following a bookmark: the log follows new messages again.
+func (h *DevTUI) clearPinFocus() bool {
+	tab := h.TabSections[h.activeTab]
+	tab.mu.Lock()
+	defer tab.mu.Unlock()
+	if tab.pinFocus == "" {
+		return false
+	}
+	tab.pinFocus = ""
+	return true
+}
+
+// pinnedLines renders the pinned block shown above the log (nil without pins).
+func (h *DevTUI) pinnedLines(ts *tabSection) []string {
+	pins, focus := ts.pinsSnapshot()
+	if len(pins) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(pins)+1)
+	for _, p := range pins {
+		marker := h.infoStyle.Render("📌 ")
+		if pinKey(p) == focus {
+			marker = h.infoStyle.Render("▶  ")
+		}
+		lines = append(lines, marker+h.textContentStyle.Render(h.formatMessage(p, true)))
+	}
+	rule := Sprintf("─ %d pinned ", len(pins))
+	if width := h.viewport.Width - lipgloss.Width(rule); width > 0 {
+		rule += Convert("─").Repeat(width).String()
+	}
+	return append(lines, h.lineHeadFootStyle.Render(rule))
+}
+
+// isPinFocus reports whether tc is the message version the log is scrolled to.
+func (ts *tabSection) isPinFocus(tc tabContent) bool {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return tc.Id != "" && ts.pinFocus == pinKey(tc)
+}
@@ -0,0 +1,143 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+func altKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true}
+}
+
+func TestPins_SurviveTruncationAndTrackedUpdates(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 10
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+
+	h.sendMessageWithHandler("FAIL: TestLogin", Msg.Error, tab, "", "", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 1 {
+		t.Fatalf("Alt+P should pin the newest message, got %d pins", len(pins))
+	}
+
+	h.sendMessageWithHandler("build 1", Msg.Info, tab, "Builder", "", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	h.sendMessageWithHandler("build 2", Msg.Info, tab, "Builder", "", "", handlerTypeLoggable)
+	for i := 0; i < 600; i++ {
+		h.sendMessageWithHandler(Sprintf("noise %d", i), Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	}
+
+	view := h.ContentView()
+	if !strings.Contains(view, "FAIL: TestLogin") || !strings.Contains(view, "build 1") {
+		t.Errorf("pins should survive truncation and tracked updates, got:\n%s", view)
+	}
+	if strings.Index(view, "FAIL: TestLogin") > strings.Index(view, "2 pinned") {
+		t.Error("pins should be rendered above the log")
+	}
+
+	h.handleKeyboard(altKey('u'))
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 0 {
+		t.Errorf("Alt+U should remove every pin, got %d", len(pins))
+	}
+}
+
+func TestPins_KeepTheOriginalOfATrackedLine(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 10
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+
+	h.sendMessageWithHandler("build 1 failed", Msg.Error, tab, "Builder", "Builder", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	h.sendMessageWithHandler("build 2 ok", Msg.Success, tab, "Builder", "Builder", "", handlerTypeLoggable)
+
+	contents := tab.contentsSnapshot()
+	if len(contents) != 1 || contents[0].Content != "build 2 ok" {
+		t.Fatalf("the tracked line should be updated in place, got %+v", contents)
+	}
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 1 || pins[0].Content != "build 1 failed" {
+		t.Errorf("the pin should keep the pinned copy, got %+v", pins)
+	}
+	view := h.ContentView()
+	if !strings.Contains(view, "build 1 failed") || !strings.Contains(view, "build 2 ok") {
+		t.Errorf("expected the pinned copy above the updated line, got:\n%s", view)
+	}
+}
+
+func TestPins_ToggleAndJump(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 5
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+
+	h.sendMessageWithHandler("first failure", Msg.Error, tab, "", "", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	for i := 0; i < 30; i++ {
+		h.sendMessageWithHandler(Sprintf("line %d", i), Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	}
+	h.handleKeyboard(altKey('p'))
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 2 {
+		t.Fatalf("expected 2 pins, got %d", len(pins))
+	}
+
+	h.handleKeyboard(altKey('j'))
+	if _, focus := tab.pinsSnapshot(); focus == "" {
+		t.Fatal("Alt+J should focus the first bookmark")
+	}
+	if h.viewport.AtBottom() {
+		t.Error("jumping to the first bookmark should scroll the log away from the bottom")
+	}
+	h.handleKeyboard(altKey('k'))
+	pins, focus := tab.pinsSnapshot()
+	if focus != pinKey(pins[1]) {
+		t.Error("Alt+K from the first bookmark should wrap to the last one")
+	}
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, focus := tab.pinsSnapshot(); focus != "" {
+		t.Error("Esc should clear the bookmark focus")
+	}
+
+	// Pinning a pinned message again unpins it
+	h.handleKeyboard(altKey('p'))
+	if pins, _ := tab.pinsSnapshot(); len(pins) != 1 || pins[0].Content != "first failure" {
+		t.Errorf("Alt+P on a pinned message should unpin it, got %+v", pins)
+	}
+}
+
+func TestPins_VersionsOfATrackedLine(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 5
+	tab := h.NewTabSection("TESTS", "").(*tabSection)
+	h.activeTab = len(h.TabSections) - 1
+
+	h.sendMessageWithHandler("build 1 failed", Msg.Error, tab, "Builder", "Builder", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	h.sendMessageWithHandler("build 2 ok", Msg.Success, tab, "Builder", "Builder", "", handlerTypeLoggable)
+	h.handleKeyboard(altKey('p'))
+	pins, _ := tab.pinsSnapshot()
+	if len(pins) != 2 || pins[1].Content != "build 2 ok" {
+		t.Fatalf("Alt+P on the updated line should pin the new version, got %+v", pins)
+	}
+	for i := 0; i < 30; i++ {
+		h.sendMessageWithHandler(Sprintf("line %d", i), Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	}
+
+	h.handleKeyboard(altKey('j'))
+	if _, expanded := tab.historyOf(pins[0].Id); !expanded {
+		t.Fatal("jumping to an older version should expand the line history")
+	}
+	lines := strings.Split(h.ContentView(), "\n")
+	if a := h.contentAnchor; a < 0 || a >= len(lines) || !strings.Contains(lines[a], "↳") || !strings.Contains(lines[a], "build 1 failed") {
+		t.Errorf("the log should be scrolled to the pinned version, anchor %d", a)
+	}
+	h.handleKeyboard(altKey('j'))
+	lines = strings.Split(h.ContentView(), "\n")
+	if a := h.contentAnchor; a < 0 || a >= len(lines) || !strings.Contains(lines[a], "build 2 ok") {
+		t.Errorf("the log should be scrolled to the current version, anchor %d", a)
+	}
+}
@@ -1,6 +1,8 @@
 package devtui
 
 import (
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 	. "github.com/tinywasm/fmt"
 	tinytime "github.com/tinywasm/time"
@@ -8,8 +10,14 @@ import (
 
 // NEW: sendMessageWithHandler sends a message with handler identification
 func (d *DevTUI) sendMessageWithHandler(content string, mt MessageType, tabSection *tabSection, handlerName string, trackingID string, handlerColor string, hType handlerType) {
+	d.sendTimedMessage(content, mt, tabSection, handlerName, trackingID, handlerColor, hType, 0)
+}
+
+// sendTimedMessage is sendMessageWithHandler for a LogClose line: duration, the time
+// since its LogOpen, is stored on the line before it reaches the channel.
+func (d *DevTUI) sendTimedMessage(content string, mt MessageType, tabSection *tabSection, handlerName string, trackingID string, handlerColor string, hType handlerType, duration time.Duration) {
 	// trackingID is now the handlerName for automatic tracking
-	_, newContent := tabSection.updateOrAddContentWithHandler(mt, content, handlerName, trackingID, handlerColor, hType)
+	_, newContent := tabSection.updateOrAddContent(mt, content, handlerName, trackingID, handlerColor, hType, false, duration)
 
 	// Always send to channel to trigger UI update
 	// prevent deadlock if channel is full
@@ -22,6 +30,29 @@ func (d *DevTUI) sendMessageWithHandler(content string, mt MessageType, tabSecti
 	}
 }
 
+// sendAnimationFrame updates the tracked line of handlerName with a LogOpen animation
+// tick. The channel copy is flagged so it doesn't count as a new (unread) message.
+func (d *DevTUI) sendAnimationFrame(content string, mt MessageType, tabSection *tabSection, handlerName string, handlerColor string) {
+	_, frame := tabSection.updateOrAddContent(mt, content, handlerName, handlerName, handlerColor, handlerTypeLoggable, true, 0)
+
+	select {
+	case d.tabContentsChan <- frame:
+	default:
+	}
+}
+
+// sendRemoteAnimationFrame is sendAnimationFrame for the daemon line with the given Id.
+func (d *DevTUI) sendRemoteAnimationFrame(tabSection *tabSection, id, content string) {
+	frame, ok := tabSection.applyAnimationFrame(id, content)
+	if !ok {
+		return
+	}
+	select {
+	case d.tabContentsChan <- frame:
+	default:
+	}
+}
+
 // formatMessage formatea un mensaje según su tipo
 // When styled is false, no ANSI escape codes are added (for MCP/LLM output).
 func (t *DevTUI) formatMessage(msg tabContent, styled bool) string {
@@ -37,11 +68,11 @@ func (t *DevTUI) formatMessage(msg tabContent, styled bool) string {
 
 	if styled {
 		content = t.applyMessageTypeStyle(msg.Content, msg.Type)
-		timeStr = t.generateTimestamp(msg.Timestamp)
+		timeStr = t.timeStyle.Render(t.formatTimestamp(msg.Timestamp, msg.openedAt))
 		handlerName = t.formatHandlerName(msg.handlerName, msg.handlerColor)
 	} else {
 		content = msg.Content
-		timeStr = t.generateTimestampPlain(msg.Timestamp)
+		timeStr = t.formatTimestamp(msg.Timestamp, msg.openedAt)
 		handlerName = t.formatHandlerNamePlain(msg.handlerName)
 	}
 
@@ -61,14 +92,6 @@ func (t *DevTUI) formatMessage(msg tabContent, styled bool) string {
 
 // Helper methods to reduce code duplication
 
-// generateTimestampPlain returns timestamp without styling
-func (t *DevTUI) generateTimestampPlain(timestamp string) string {
-	if timestamp != "" {
-		return tinytime.FormatTime(timestamp)
-	}
-	return "--:--:--"
-}
-
 // formatHandlerNamePlain returns handler name without styling (just padded)
 func (t *DevTUI) formatHandlerNamePlain(handlerName string) string {
 	if handlerName == "" {
@@ -93,12 +116,17 @@ func (t *DevTUI) applyMessageTypeStyle(content string, msgType MessageType) stri
 	}
 }
 
-func (t *DevTUI) generateTimestamp(timestamp string) string {
-	if timestamp != "" {
-		// FormatTime accepts any (string, int64, etc.) and returns "HH:MM:SS"
-		return t.timeStyle.Render(tinytime.FormatTime(timestamp))
+// timestampTime converts a tabContent timestamp (unixid nanoseconds, optionally
+// followed by ".<session>") to time.Time. Fallback "HH:MM:SS" timestamps report false.
+func timestampTime(timestamp string) (time.Time, bool) {
+	if i := Index(timestamp, "."); i >= 0 {
+		timestamp = timestamp[:i]
+	}
+	nano, err := Convert(timestamp).Int64()
+	if err != nil || nano <= 0 {
+		return time.Time{}, false
 	}
-	return t.timeStyle.Render("--:--:--")
+	return time.Unix(0, nano), true
 }
 
 func (t *DevTUI) formatHandlerName(handlerName string, handlerColor string) string {
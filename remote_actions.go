@@ -0,0 +1,170 @@
+//go:build !wasm
+
+package devtui
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/tinywasm/context"
+	. "github.com/tinywasm/fmt"
+	"github.com/tinywasm/mcp"
+)
+
+// Remote actions: a remote field's edit or execution is a tinywasm/action
+// JSON-RPC call whose answer is awaited. The field label shows the outcome
+// (pending, ok for a moment, failed); on failure the last value the daemon confirmed
+// is restored and the daemon's error is logged into the field's tab.
+
+// actionStatus is the outcome of the last action of a remote field.
+type actionStatus int
+
+const (
+	actionIdle actionStatus = iota
+	actionPending
+	actionOK
+	actionFailed
+)
+
+// actionOKDisplay is how long a succeeded action keeps its badge.
+var actionOKDisplay = 2 * time.Second
+
+// remoteField is the daemon side of a remote field: its StateEntry, read by the
+// field's handler closures and updated by reconciliation, and the outcome of its
+// actions. The entry is guarded because daemon answers arrive on other goroutines.
+type remoteField struct {
+	client *mcp.Client
+	tui    *DevTUI       // nil in tests: no logging / refresh
+	daemon *remoteDaemon // daemon that reported the field (see remote_daemon.go)
+	ts     *tabSection
+
+	mu        sync.Mutex
+	entry     StateEntry
+	confirmed string // last value the daemon reported or accepted: the rollback target
+	status    actionStatus
+	seq       int // last action sent: only its answer updates the status
+}
+
+// get returns a copy of the entry.
+func (r *remoteField) get() StateEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entry
+}
+
+// daemonURL returns the URL of the daemon that reported the field ("" if unknown).
+func (r *remoteField) daemonURL() string {
+	if r.daemon == nil {
+		return ""
+	}
+	return r.daemon.url
+}
+
+// update replaces the entry with a newer daemon snapshot.
+func (r *remoteField) update(entry StateEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry = entry
+	r.confirmed = entry.Value
+}
+
+// changeValue sets the value optimistically and sends it; a rejection restores
+// the last value the daemon confirmed, unless it was changed again meanwhile.
+func (r *remoteField) changeValue(v string) {
+	r.mu.Lock()
+	r.entry.Value = v
+	r.mu.Unlock()
+	r.send(v, func(e *StateEntry, err error) {
+		switch {
+		case err == nil:
+			r.confirmed = v
+		case e.Value == v:
+			e.Value = r.confirmed
+		}
+	})
+}
+
+// send calls tinywasm/action with value; settle (optional) is called with the
+// entry locked once the daemon answers, to confirm or undo the optimistic update.
+func (r *remoteField) send(value string, settle func(e *StateEntry, err error)) {
+	r.mu.Lock()
+	key := r.entry.Shortcut
+	if key == "" || r.client == nil {
+		r.mu.Unlock()
+		return
+	}
+	r.seq++
+	seq := r.seq
+	r.status = actionPending
+	r.mu.Unlock()
+
+	r.client.Call(context.Background(), "tinywasm/action", &ActionArgs{
+		Key:   key,
+		Value: value,
+	}, func(result []byte, err error) {
+		if err == nil && actionRejected(result) {
+			err = Err(Sprintf("action '%s' rejected by the daemon", key))
+		}
+		r.finish(seq, err, settle)
+	})
+}
+
+// actionRejected reports a `false` result; any other result is a success.
+func actionRejected(result []byte) bool {
+	var ok bool
+	return json.Unmarshal(result, &ok) == nil && !ok
+}
+
+// finish records the answer of action seq.
+func (r *remoteField) finish(seq int, err error, settle func(e *StateEntry, err error)) {
+	r.mu.Lock()
+	latest := seq == r.seq
+	if latest {
+		r.status = actionOK
+		if err != nil {
+			r.status = actionFailed
+		}
+	}
+	if settle != nil {
+		settle(&r.entry, err)
+	}
+	entry := r.entry
+	okDisplay := actionOKDisplay // read before the label shows the outcome
+	r.mu.Unlock()
+
+	if err != nil {
+		if r.tui != nil && r.ts != nil {
+			r.tui.sendMessageWithHandler(Sprintf("%s: %s", entry.HandlerName, err.Error()), Msg.Error, r.ts, entry.HandlerName, "", entry.HandlerColor, handlerTypeLoggable)
+		}
+	} else if latest {
+		time.AfterFunc(okDisplay, func() {
+			r.mu.Lock()
+			if r.seq == seq && r.status == actionOK {
+				r.status = actionIdle
+			}
+			r.mu.Unlock()
+			if r.tui != nil {
+				r.tui.RefreshUI()
+			}
+		})
+	}
+	if r.tui != nil {
+		r.tui.RefreshUI()
+	}
+}
+
+// label returns the field label with the badge of the last action.
+func (r *remoteField) label() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.status {
+	case actionPending:
+		return r.entry.Label + " ⋯"
+	case actionOK:
+		return r.entry.Label + " ✔"
+	case actionFailed:
+		return r.entry.Label + " ✖"
+	}
+	return r.entry.Label
+}
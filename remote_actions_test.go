@@ -0,0 +1,124 @@
+//go:build !wasm
+
+package devtui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/tinywasm/fmt"
+	"github.com/tinywasm/mcp"
+)
+
+// newActionDaemon answers tinywasm/action: value "bad" gets a JSON-RPC error,
+// "no" a false result, anything else true.
+func newActionDaemon(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any        `json:"id"`
+			Params ActionArgs `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Params.Value {
+		case "bad":
+			resp["error"] = map[string]any{"code": -32000, "message": "invalid port"}
+		case "no":
+			resp["result"] = false
+		default:
+			resp["result"] = true
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func waitLabel(t *testing.T, f *field, suffix string) {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for !strings.HasSuffix(f.handler.Label(), suffix) {
+		select {
+		case <-deadline:
+			t.Fatalf("expected label ending in %q, got %q", suffix, f.handler.Label())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestRemoteAction_RollbackAndErrorLog(t *testing.T) {
+	daemon := newActionDaemon(t)
+	defer daemon.Close()
+
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: daemon.URL + "/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	f := newRemoteField(StateEntry{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit,
+		Label: "Port", Value: "8080", Shortcut: "Port"}, mcp.NewClient(daemon.URL, ""), section, tui)
+
+	f.handler.Change("bad")
+	waitLabel(t, f, "✖")
+	if f.Value() != "8080" {
+		t.Errorf("a failed action should roll the value back, got %q", f.Value())
+	}
+	contents := section.contentsSnapshot()
+	if len(contents) != 1 || contents[0].Type != Msg.Error || !strings.Contains(contents[0].Content, "invalid port") {
+		t.Errorf("the daemon error should be logged in the tab, got %+v", contents)
+	}
+
+	f.handler.Change("no")
+	waitLabel(t, f, "✖")
+	if f.Value() != "8080" {
+		t.Errorf("a false result is a rejection, got value %q", f.Value())
+	}
+}
+
+func TestRemoteAction_RollbackToConfirmedValue(t *testing.T) {
+	daemon := newActionDaemon(t)
+	defer daemon.Close()
+
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: daemon.URL + "/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	f := newRemoteField(StateEntry{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit,
+		Label: "Port", Value: "8080", Shortcut: "Port"}, mcp.NewClient(daemon.URL, ""), section, tui)
+
+	f.handler.Change("9090")
+	waitLabel(t, f, "✔")
+
+	// Both edits are rejected: the value goes back to the accepted 9090, not to
+	// the optimistic "no" the second edit replaced
+	f.handler.Change("no")
+	f.handler.Change("bad")
+	deadline := time.After(2 * time.Second)
+	for f.Value() == "bad" {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the rollback")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if f.Value() != "9090" {
+		t.Errorf("a rejected edit should restore the last confirmed value, got %q", f.Value())
+	}
+}
+
+func TestRemoteAction_SuccessBadgeFades(t *testing.T) {
+	daemon := newActionDaemon(t)
+	defer daemon.Close()
+	defer func(d time.Duration) { actionOKDisplay = d }(actionOKDisplay)
+	actionOKDisplay = 50 * time.Millisecond
+
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: daemon.URL + "/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	f := newRemoteField(StateEntry{TabTitle: "BUILD", HandlerName: "Build", HandlerType: HandlerTypeExecution,
+		Label: "Build", Shortcut: "Build"}, mcp.NewClient(daemon.URL, ""), section, tui)
+
+	f.handler.Execute()
+	waitLabel(t, f, "✔")
+	waitLabel(t, f, "Build")
+	if len(section.contentsSnapshot()) != 0 {
+		t.Error("a successful action logs nothing")
+	}
+}
@@ -0,0 +1,180 @@
+package devtui
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	. "github.com/tinywasm/fmt"
+	"github.com/tinywasm/mcp"
+)
+
+// Multi-daemon client mode: every TuiConfig.Endpoints entry (or the single
+// ClientURL) is a remoteDaemon with its own SSE connection, state snapshot,
+// tabs and mcp.Client, so remote fields send their actions to the daemon that
+// reported them. A named daemon's tabs are namespaced "<Name>/<TAB>" unless
+// TuiConfig.MergeTabs folds the same-titled tabs of every daemon into one.
+
+// Endpoint is one daemon watched in client mode.
+type Endpoint struct {
+	Name   string // tab namespace and connection badge label, eg: "front" ("" = no namespace)
+	URL    string // e.g. http://localhost:3030/logs
+	APIKey string // Bearer token for secured daemon; empty = open/local
+}
+
+// remoteDaemon is the client side of one daemon.
+type remoteDaemon struct {
+	tui    *DevTUI
+	name   string
+	url    string
+	apiKey string
+	client *mcp.Client // JSON-RPC client of the daemon's /mcp endpoint
+	conn   *sseConn    // SSE connection state and reconnection backoff
+
+	stateMu sync.Mutex
+	state   []StateEntry                  // last snapshot applied, base of "state-patch" events (see state_events.go)
+	synced  bool                          // a first snapshot was applied
+	pending []StatePatch                  // patches received before the first snapshot
+	tabs    map[tabSource]map[string]bool // displayed titles of the tabs it last reported, by state or tabs list
+
+	logTabsMu sync.Mutex
+	logTabs   map[string]*tabSection // tab of each log line TabTitle already found (see logTab)
+}
+
+// newRemoteDaemon returns the client of an endpoint.
+// URL = "http://host:port/logs" → JSON-RPC base URL = "http://host:port"
+func newRemoteDaemon(h *DevTUI, e Endpoint) *remoteDaemon {
+	return &remoteDaemon{
+		tui:    h,
+		name:   e.Name,
+		url:    e.URL,
+		apiKey: e.APIKey,
+		client: mcp.NewClient(strings.TrimSuffix(e.URL, "/logs"), e.APIKey),
+		conn:   newSSEConn(),
+	}
+}
+
+// clientEndpoints returns TuiConfig.Endpoints, or ClientURL/APIKey as a single unnamed endpoint.
+func (c *TuiConfig) clientEndpoints() []Endpoint {
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints
+	}
+	if c.ClientURL == "" {
+		return nil
+	}
+	return []Endpoint{{URL: c.ClientURL, APIKey: c.APIKey}}
+}
+
+// validEndpoints drops, and logs, the endpoints that would be mistaken for
+// another one when several are watched: names namespace the tabs and key
+// ConnectionStatuses, URLs identify the daemon of remote shortcuts, so both
+// must be set and unique. A single endpoint is used as is.
+func validEndpoints(endpoints []Endpoint, logger func(messages ...any)) []Endpoint {
+	if len(endpoints) < 2 {
+		return endpoints
+	}
+	var valid []Endpoint
+	names := make(map[string]bool, len(endpoints))
+	urls := make(map[string]bool, len(endpoints))
+	for _, e := range endpoints {
+		reason := ""
+		switch {
+		case e.Name == "":
+			reason = "a name is required when several endpoints are set"
+		case names[e.Name]:
+			reason = "duplicate name"
+		case e.URL == "":
+			reason = "a URL is required"
+		case urls[e.URL]:
+			reason = "duplicate URL"
+		}
+		if reason != "" {
+			if logger != nil {
+				logger("Endpoint error:", Sprintf("'%s' (%s) ignored: %s", e.Name, e.URL, reason))
+			}
+			continue
+		}
+		names[e.Name], urls[e.URL] = true, true
+		valid = append(valid, e)
+	}
+	return valid
+}
+
+// primaryDaemon returns the first daemon, the only one of a ClientURL setup,
+// or nil outside client mode. Daemons are only created by NewTUI.
+func (h *DevTUI) primaryDaemon() *remoteDaemon {
+	if len(h.daemons) == 0 {
+		return nil
+	}
+	return h.daemons[0]
+}
+
+// tabTitle returns the displayed title of one of the daemon's tabs.
+func (d *remoteDaemon) tabTitle(title string) string {
+	if d.name == "" || title == "" || d.tui.MergeTabs {
+		return title
+	}
+	return d.name + "/" + title
+}
+
+// tabTitles returns the displayed tab titles present in a state snapshot.
+func (d *remoteDaemon) tabTitles(entries []StateEntry) map[string]bool {
+	titles := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		titles[d.tabTitle(entry.TabTitle)] = true
+	}
+	return titles
+}
+
+// syncTabs records the tabs the daemon reports from source (a state snapshot
+// or a tabs list) and removes its remote tabs missing from titles that source
+// may remove (see tabSource). A merged tab another daemon still reports is
+// handed over to it instead; the other daemons' tabs are left alone.
+func (d *remoteDaemon) syncTabs(titles map[string]bool, source tabSource) {
+	h := d.tui
+	if d.tabs == nil {
+		d.tabs = make(map[tabSource]map[string]bool)
+	}
+	d.tabs[source] = titles
+	keep := maps.Clone(titles)
+	for _, s := range h.TabSections {
+		if !s.remote || titles[s.Title] {
+			continue
+		}
+		if s.daemon == d && source == tabFromState && s.source != tabFromState {
+			keep[s.Title] = true // created by log lines or a tabs list
+			continue
+		}
+		if s.daemon == d {
+			for _, other := range h.daemons {
+				if other != d && other.reports(s.Title) {
+					s.daemon = other
+					s.source = tabFromState
+					if other.tabs[tabFromList][s.Title] {
+						s.source = tabFromList
+					}
+					break
+				}
+			}
+		}
+		if s.daemon != d {
+			keep[s.Title] = true
+		}
+	}
+	h.removeRemoteTabs(keep)
+}
+
+// reports tells whether the daemon's last state snapshot or tabs list had title.
+func (d *remoteDaemon) reports(title string) bool {
+	return d.tabs[tabFromState][title] || d.tabs[tabFromList][title]
+}
+
+// ConnectionStatuses returns the SSE connection state of every client mode
+// endpoint, keyed by Endpoint.Name.
+func (h *DevTUI) ConnectionStatuses() map[string]ConnectionState {
+	statuses := make(map[string]ConnectionState, len(h.daemons))
+	for _, d := range h.daemons {
+		statuses[d.name], _ = d.conn.snapshot()
+	}
+	return statuses
+}
@@ -0,0 +1,163 @@
+//go:build !wasm
+
+package devtui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newMultiDaemonTUI(mergeTabs bool, urls ...string) *DevTUI {
+	config := &TuiConfig{ClientMode: true, MergeTabs: mergeTabs, Endpoints: []Endpoint{
+		{Name: "front", URL: urls[0] + "/logs"},
+		{Name: "back", URL: urls[1] + "/logs"},
+	}}
+	return NewTUI(config)
+}
+
+func TestMultiDaemon_NamespacedTabs(t *testing.T) {
+	tui := newMultiDaemonTUI(false, "http://localhost:1", "http://localhost:2")
+	front, back := tui.daemons[0], tui.daemons[1]
+
+	front.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"3000"}]`)
+	back.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"8080"}]`)
+	if got := tabTitles(tui); got != "front/BUILD,back/BUILD" {
+		t.Fatalf("expected one namespaced tab per daemon, got %s", got)
+	}
+	if v := tui.tabByTitle("back/BUILD").FieldHandlers[0].Value(); v != "8080" {
+		t.Errorf("expected the back daemon value, got %q", v)
+	}
+
+	back.handleLogEvent(`{"id":"1","timestamp":"1","content":"listening","tab_title":"LOGS","handler_name":"Srv","handler_type":4}`)
+	front.handleStateEvent(`[{"tab_title":"TEST","handler_name":"Run","handler_type":2,"label":"Run"}]`)
+	if got := tabTitles(tui); got != "back/BUILD,back/LOGS,front/TEST" {
+		t.Errorf("a snapshot should only remove its own daemon's tabs, got %s", got)
+	}
+}
+
+func TestMultiDaemon_MergedTabs(t *testing.T) {
+	tui := newMultiDaemonTUI(true, "http://localhost:1", "http://localhost:2")
+	front, back := tui.daemons[0], tui.daemons[1]
+
+	front.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"3000"}]`)
+	back.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"8080"}]`)
+	if got := tabTitles(tui); got != "BUILD" {
+		t.Fatalf("expected the tabs merged, got %s", got)
+	}
+	section := tui.tabByTitle("BUILD")
+	if len(section.FieldHandlers) != 2 {
+		t.Fatalf("expected a field per daemon, got %d", len(section.FieldHandlers))
+	}
+
+	front.handleStateEvent(`[{"tab_title":"TEST","handler_name":"Run","handler_type":2,"label":"Run"}]`)
+	if got := tabTitles(tui); got != "BUILD,TEST" {
+		t.Errorf("a merged tab stays while another daemon reports it, got %s", got)
+	}
+	if len(section.FieldHandlers) != 1 || section.FieldHandlers[0].Value() != "8080" {
+		t.Errorf("only the front field should be removed, got %d fields", len(section.FieldHandlers))
+	}
+}
+
+func TestMultiDaemon_ActionRouting(t *testing.T) {
+	var frontCalls, backCalls atomic.Int32
+	counting := func(calls *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":true}`))
+		}))
+	}
+	frontSrv, backSrv := counting(&frontCalls), counting(&backCalls)
+	defer frontSrv.Close()
+	defer backSrv.Close()
+
+	tui := newMultiDaemonTUI(false, frontSrv.URL, backSrv.URL)
+	tui.daemons[0].handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"label":"Port","value":"3000","shortcut":"Port"}]`)
+	tui.daemons[1].handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"8080"}]`)
+
+	f := tui.tabByTitle("front/BUILD").FieldHandlers[0]
+	f.handler.Change("3001")
+	waitLabel(t, f, "✔")
+	if frontCalls.Load() != 1 || backCalls.Load() != 0 {
+		t.Errorf("the action should reach the front daemon only, got front=%d back=%d", frontCalls.Load(), backCalls.Load())
+	}
+}
+
+func TestMultiDaemon_ConnectionBadges(t *testing.T) {
+	tui := newMultiDaemonTUI(false, "http://localhost:1", "http://localhost:2")
+	tui.daemons[0].conn.setState(ConnLive)
+	tui.daemons[1].conn.setState(ConnAuthFailed)
+
+	badge := tui.connectionBadge()
+	if !strings.Contains(badge, "front ● live") || !strings.Contains(badge, "back ✖ auth failed") {
+		t.Errorf("expected a badge per endpoint, got %q", badge)
+	}
+	statuses := tui.ConnectionStatuses()
+	if statuses["front"] != ConnLive || statuses["back"] != ConnAuthFailed {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+}
+
+func TestMultiDaemon_InvalidEndpoints(t *testing.T) {
+	var logged []string
+	tui := NewTUI(&TuiConfig{ClientMode: true, Logger: func(messages ...any) {
+		logged = append(logged, messages[len(messages)-1].(string))
+	}, Endpoints: []Endpoint{
+		{Name: "front", URL: "http://localhost:1/logs"},
+		{URL: "http://localhost:2/logs"},
+		{Name: "front", URL: "http://localhost:3/logs"},
+		{Name: "back", URL: "http://localhost:1/logs"},
+		{Name: "back", URL: "http://localhost:4/logs"},
+	}})
+	if len(tui.daemons) != 2 || tui.daemons[0].name != "front" || tui.daemons[1].url != "http://localhost:4/logs" {
+		t.Fatalf("expected front and the second back, got %d daemons", len(tui.daemons))
+	}
+	if len(logged) != 3 || !strings.Contains(logged[2], "duplicate URL") {
+		t.Errorf("each rejected endpoint should be logged, got %q", logged)
+	}
+}
+
+func TestMultiDaemon_BadgesFitTheHeader(t *testing.T) {
+	tui := newMultiDaemonTUI(false, "http://localhost:1", "http://localhost:2")
+	tui.NewTabSection("BUILD", "")
+	for _, d := range tui.daemons {
+		d.conn.setState(ConnReconnecting)
+		d.conn.retryAt = time.Now().Add(30 * time.Second) // "↻ reconnecting in 30s"
+	}
+
+	for _, width := range []int{80, 60, 45} {
+		tui.viewport.Width = width
+		header := tui.headerView()
+		if w := lipgloss.Width(header); w > width || strings.Contains(header, "\n") {
+			t.Errorf("width %d: the header should fit on one line, got %d: %q", width, w, header)
+		}
+	}
+	if header := tui.headerView(); !strings.Contains(header, "+") {
+		t.Errorf("badges left out should be counted, got %q", header)
+	}
+}
+
+func TestMultiDaemon_ShortcutOwners(t *testing.T) {
+	tui := newMultiDaemonTUI(true, "http://localhost:1", "http://localhost:2")
+	front, back := tui.daemons[0], tui.daemons[1]
+
+	front.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"3000","shortcuts":[{"p":"port"}]}]`)
+	back.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"8080","shortcuts":[{"p":"port"}]}]`)
+	if n := len(tui.ShortcutConflicts()); n != 1 {
+		t.Fatalf("same-named handlers of two daemons should conflict, got %d conflicts", n)
+	}
+
+	front.handleStateEvent(`[{"tab_title":"BUILD","handler_name":"Other","handler_type":1,"value":"1"}]`)
+	entry, ok := tui.shortcutRegistry.Get("p")
+	if !ok || entry.Daemon != back.url {
+		t.Fatalf("removing the front field should keep the back shortcut, got %+v", entry)
+	}
+	if section := tui.tabByTitle("BUILD"); section.FieldHandlers[entry.FieldIndex].Value() != "8080" {
+		t.Errorf("the back shortcut should point to the back field, got index %d", entry.FieldIndex)
+	}
+}
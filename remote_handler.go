@@ -3,100 +3,96 @@
 package devtui
 
 import (
-	"github.com/tinywasm/context"
 	"github.com/tinywasm/mcp"
 )
 
-// newRemoteField constructs a *field populated from a StateEntry.
+// buildRemoteField constructs a *field populated from a StateEntry.
 // Uses anyHandler closures directly — no intermediate interface types needed.
-// The entry pointer is captured so optimistic value updates stay in sync.
-// The tui reference is used to register shortcuts in the ShortcutRegistry.
-func newRemoteField(entry StateEntry, client *mcp.Client, ts *tabSection, tui *DevTUI) *field {
-	e := entry // local copy captured by closures
+// The closures read the entry through field.remote, so optimistic value updates
+// and state reconciliation (which updates labels and values in place) stay in sync.
+// The tui reference (optional) reports action failures in the tab. Shortcuts are
+// registered by the caller once the field has its index (see registerRemoteShortcuts).
+func buildRemoteField(entry StateEntry, client *mcp.Client, ts *tabSection, tui *DevTUI) *field {
+	r := &remoteField{client: client, tui: tui, ts: ts, entry: entry, confirmed: entry.Value}
+	name := func() string { return r.get().HandlerName }
 	var anyH *anyHandler
 
-	switch handlerType(e.HandlerType) {
+	switch handlerType(entry.HandlerType) {
 	case handlerTypeDisplay:
 		anyH = &anyHandler{
 			handlerType:  handlerTypeDisplay,
-			handlerColor: e.HandlerColor,
-			nameFunc:     func() string { return e.HandlerName },
-			valueFunc:    func() string { return e.Value },
-			contentFunc:  func() string { return e.Value },
+			handlerColor: entry.HandlerColor,
+			nameFunc:     name,
+			valueFunc:    func() string { return r.get().Value },
+			contentFunc:  func() string { return r.get().Value },
 			editableFunc: func() bool { return false },
 		}
 	case handlerTypeEdit:
 		anyH = &anyHandler{
 			handlerType:  handlerTypeEdit,
-			handlerColor: e.HandlerColor,
-			nameFunc:     func() string { return e.HandlerName },
-			labelFunc:    func() string { return e.Label },
-			valueFunc:    func() string { return e.Value },
+			handlerColor: entry.HandlerColor,
+			nameFunc:     name,
+			labelFunc:    r.label,
+			valueFunc:    func() string { return r.get().Value },
 			editableFunc: func() bool { return true },
-			changeFunc: func(v string) {
-				e.Value = v // optimistic update
-				postAction(client, e.Shortcut, v)
-			},
+			changeFunc:   r.changeValue, // optimistic update, rolled back if the daemon rejects it
 		}
 	case handlerTypeExecution:
 		anyH = &anyHandler{
 			handlerType:  handlerTypeExecution,
-			handlerColor: e.HandlerColor,
-			nameFunc:     func() string { return e.HandlerName },
-			labelFunc:    func() string { return e.Label },
-			valueFunc:    func() string { return e.Label },
+			handlerColor: entry.HandlerColor,
+			nameFunc:     name,
+			labelFunc:    r.label,
+			valueFunc:    func() string { return r.get().Label },
 			editableFunc: func() bool { return false },
-			executeFunc:  func() { postAction(client, e.Shortcut, "") },
-			changeFunc:   func(_ string) { postAction(client, e.Shortcut, "") },
+			executeFunc:  func() { r.send("", nil) },
+			changeFunc:   func(_ string) { r.send("", nil) },
 		}
 	case handlerTypeInteractive:
 		anyH = &anyHandler{
 			handlerType:  handlerTypeInteractive,
-			handlerColor: e.HandlerColor,
-			nameFunc:     func() string { return e.HandlerName },
-			labelFunc:    func() string { return e.Label },
-			valueFunc:    func() string { return e.Value },
+			handlerColor: entry.HandlerColor,
+			nameFunc:     name,
+			labelFunc:    r.label,
+			valueFunc:    func() string { return r.get().Value },
 			editableFunc: func() bool { return true },
 			editModeFunc: func() bool { return false },
 			changeFunc: func(v string) {
-				postAction(client, e.Shortcut, v)
+				r.send(v, nil)
 			},
 		}
 	default:
 		return nil // HandlerTypeLoggable — no field, logs arrive via SSE
 	}
 
-	f := &field{handler: anyH, parentTab: ts, isRemote: true}
+	return &field{handler: anyH, parentTab: ts, isRemote: true, remote: r}
+}
 
-	// Register shortcuts from StateEntry.Shortcuts in the TUI's registry
+// registerRemoteShortcuts registers the shortcuts of a StateEntry reported by
+// the daemon at daemonURL in the TUI's registry, for the field at fieldIndex of
+// tab tabIndex.
+func registerRemoteShortcuts(tui *DevTUI, daemonURL string, e StateEntry, tabIndex, fieldIndex int) {
 	if tui != nil && tui.shortcutRegistry != nil && len(e.Shortcuts) > 0 {
-		fieldIndex := len(ts.FieldHandlers)
-		tabIndex := ts.Index
 		for _, m := range e.Shortcuts {
-			for key := range m {
+			for value := range m {
+				key, enabled := tui.keymap.shortcutKey(e.HandlerName, value) // keymap file may remap or disable it
+				if !enabled {
+					continue
+				}
 				entry := &ShortcutEntry{
 					Key:         key,
-					Description: key, // Use key as description for remote shortcuts
+					Description: value, // Use key as description for remote shortcuts
 					TabIndex:    tabIndex,
 					FieldIndex:  fieldIndex,
 					HandlerName: e.HandlerName,
-					Value:       key,
+					Value:       value,
+					Remote:      true,
+					Daemon:      daemonURL,
+				}
+				if err := tui.shortcutRegistry.TryRegister(key, entry); err != nil && tui.Logger != nil {
+					tui.Logger(err)
 				}
-				tui.shortcutRegistry.Register(key, entry)
 			}
 		}
 	}
-
-	return f
-}
-
-// postAction sends a tinywasm/action JSON-RPC call to the daemon (fire-and-forget).
-func postAction(client *mcp.Client, shortcut, value string) {
-	if shortcut == "" || client == nil {
-		return
-	}
-	client.Dispatch(context.Background(), "tinywasm/action", &ActionArgs{
-		Key:   shortcut,
-		Value: value,
-	})
 }
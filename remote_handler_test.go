@@ -8,6 +8,16 @@ import (
 	"github.com/tinywasm/mcp"
 )
 
+// newRemoteField builds the field of a StateEntry and registers its shortcuts
+// for the next field index of ts, as a reconciliation adding it would.
+func newRemoteField(entry StateEntry, client *mcp.Client, ts *tabSection, tui *DevTUI) *field {
+	f := buildRemoteField(entry, client, ts, tui)
+	if f != nil {
+		registerRemoteShortcuts(tui, "", entry, ts.Index, len(ts.FieldHandlers))
+	}
+	return f
+}
+
 // TestRemoteField_RegistersShortcuts verifies shortcuts from StateEntry are registered
 func TestRemoteField_RegistersShortcuts(t *testing.T) {
 	tui := &DevTUI{
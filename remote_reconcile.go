@@ -0,0 +1,132 @@
+package devtui
+
+import (
+	"maps"
+	"slices"
+)
+
+// State reconciliation: a new daemon snapshot is diffed against the current
+// remote fields by tab and handler name instead of clearing and rebuilding them.
+// Fields still present keep their object, so the selected field, a half-typed
+// tempEditValue and the cursor survive; labels, values and colors are updated
+// in place through field.remote. Shortcuts are only re-registered for new
+// fields or changed shortcut lists; fields that moved get their FieldIndex fixed.
+
+// reconcileRemoteHandlers applies a state snapshot of the daemon to every tab.
+func (d *remoteDaemon) reconcileRemoteHandlers(entries []StateEntry) {
+	wanted := make(map[*tabSection][]StateEntry)
+	for _, entry := range entries {
+		if section := d.remoteTab(entry.TabTitle, "", tabFromState); section != nil {
+			wanted[section] = append(wanted[section], entry)
+		}
+	}
+	for _, section := range d.tui.TabSections {
+		d.reconcileTab(section, wanted[section])
+	}
+}
+
+// reconcileTab diffs the daemon's remote fields of one tab against its
+// snapshot entries; the fields of other daemons are left alone.
+func (d *remoteDaemon) reconcileTab(ts *tabSection, entries []StateEntry) {
+	h := d.tui
+	var focused *field
+	if ts.IndexActiveEditField >= 0 && ts.IndexActiveEditField < len(ts.FieldHandlers) {
+		focused = ts.FieldHandlers[ts.IndexActiveEditField]
+	}
+
+	byName := make(map[string]StateEntry, len(entries))
+	for _, e := range entries {
+		byName[e.HandlerName] = e
+	}
+	present := make(map[string]bool, len(entries))
+	reregister := make(map[*field]bool)
+	fields := make([]*field, 0, len(ts.FieldHandlers)+len(entries))
+
+	for _, f := range ts.FieldHandlers {
+		if !f.isRemote || f.remote == nil || f.remote.daemon != d {
+			fields = append(fields, f)
+			continue
+		}
+		current := f.remote.get()
+		name := current.HandlerName
+		entry, ok := byName[name]
+		if !ok || present[name] || entry.HandlerType != current.HandlerType {
+			h.shortcutRegistry.unregisterRemote(d.url, ts.Index, name) // gone (or a different kind of field now)
+			continue
+		}
+		present[name] = true
+		if !slices.EqualFunc(entry.Shortcuts, current.Shortcuts, maps.Equal) {
+			h.shortcutRegistry.unregisterRemote(d.url, ts.Index, name)
+			reregister[f] = true
+		}
+		f.remote.update(entry)
+		f.handler.handlerColor = entry.HandlerColor
+		fields = append(fields, f)
+	}
+
+	for _, entry := range entries {
+		if present[entry.HandlerName] {
+			continue
+		}
+		present[entry.HandlerName] = true
+		if f := buildRemoteField(entry, d.client, ts, h); f != nil {
+			f.remote.daemon = d
+			reregister[f] = true
+			fields = append(fields, f)
+		}
+	}
+
+	ts.FieldHandlers = fields
+	for i, f := range fields {
+		f.index = i
+		if !f.isRemote || f.remote == nil {
+			continue
+		}
+		if entry := f.remote.get(); reregister[f] {
+			registerRemoteShortcuts(h, d.url, entry, ts.Index, i)
+		} else {
+			h.shortcutRegistry.moveRemote(f.remote.daemonURL(), ts.Index, entry.HandlerName, i) // fields of other daemons move too
+		}
+	}
+
+	// Keep the focus on the same field; leave edit mode if it is gone
+	if index := slices.Index(fields, focused); index >= 0 {
+		ts.IndexActiveEditField = index
+	} else if focused != nil {
+		ts.IndexActiveEditField = max(0, min(ts.IndexActiveEditField, len(fields)-1))
+		if h.activeTab == ts.Index {
+			h.editModeActivated = false
+		}
+	}
+}
+
+// unregisterRemote drops the remote shortcuts of a daemon's handler in a tab.
+func (sr *ShortcutRegistry) unregisterRemote(daemonURL string, tabIndex int, handlerName string) {
+	if sr == nil {
+		return
+	}
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.entries = slices.DeleteFunc(sr.entries, func(e *ShortcutEntry) bool {
+		return e.isRemoteOf(daemonURL, tabIndex, handlerName)
+	})
+}
+
+// moveRemote points the remote shortcuts of a daemon's handler to its new field index.
+func (sr *ShortcutRegistry) moveRemote(daemonURL string, tabIndex int, handlerName string, fieldIndex int) {
+	if sr == nil {
+		return
+	}
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	for _, e := range sr.entries {
+		if e.isRemoteOf(daemonURL, tabIndex, handlerName) {
+			e.FieldIndex = fieldIndex
+		}
+	}
+}
+
+// isRemoteOf reports whether e is a shortcut of the daemon's handler in a tab.
+func (e *ShortcutEntry) isRemoteOf(daemonURL string, tabIndex int, handlerName string) bool {
+	return e.Remote && e.Daemon == daemonURL && e.TabIndex == tabIndex && e.HandlerName == handlerName
+}
@@ -0,0 +1,70 @@
+package devtui
+
+import "testing"
+
+func TestReconcileRemoteHandlers_KeepsFocusAndEditBuffer(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	tui.activeTab = section.Index
+
+	tui.primaryDaemon().reconcileRemoteHandlers([]StateEntry{
+		{TabTitle: "BUILD", HandlerName: "Mode", HandlerType: HandlerTypeEdit, Label: "Mode", Value: "dev", Shortcut: "Mode",
+			Shortcuts: []map[string]string{{"d": "debug"}}},
+		{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit, Label: "Port", Value: "8080", Shortcut: "Port",
+			Shortcuts: []map[string]string{{"p": "port"}}},
+	})
+	if len(section.FieldHandlers) != 2 {
+		t.Fatalf("expected 2 remote fields, got %d", len(section.FieldHandlers))
+	}
+	port := section.FieldHandlers[1]
+	section.IndexActiveEditField = 1
+	tui.editModeActivated = true
+	port.tempEditValue = "90"
+	registered, _ := tui.shortcutRegistry.Get("p")
+
+	// Mode is gone, Port's label changes, Build is new
+	tui.primaryDaemon().reconcileRemoteHandlers([]StateEntry{
+		{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit, Label: "Port (busy)", Value: "8081", Shortcut: "Port",
+			Shortcuts: []map[string]string{{"p": "port"}}},
+		{TabTitle: "BUILD", HandlerName: "Build", HandlerType: HandlerTypeExecution, Label: "Build", Shortcut: "Build"},
+	})
+
+	if len(section.FieldHandlers) != 2 || section.FieldHandlers[0] != port {
+		t.Fatalf("Port should be kept as the same field and Build appended, got %d fields", len(section.FieldHandlers))
+	}
+	if port.handler.Label() != "Port (busy)" || port.Value() != "8081" {
+		t.Errorf("label and value should be updated in place, got %q %q", port.handler.Label(), port.Value())
+	}
+	if section.IndexActiveEditField != 0 || !tui.editModeActivated || port.tempEditValue != "90" {
+		t.Errorf("focus and edit buffer should be kept, got index %d edit %v buffer %q",
+			section.IndexActiveEditField, tui.editModeActivated, port.tempEditValue)
+	}
+	if _, ok := tui.shortcutRegistry.Get("d"); ok {
+		t.Error("shortcuts of removed fields should be unregistered")
+	}
+	if e, ok := tui.shortcutRegistry.Get("p"); !ok || e != registered || e.FieldIndex != 0 {
+		t.Errorf("unchanged shortcuts should be kept and follow their field, got %+v", e)
+	}
+}
+
+func TestReconcileRemoteHandlers_FocusedFieldRemoved(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	tui.NewTabSection("OTHER", "")
+	tui.primaryDaemon().reconcileRemoteHandlers([]StateEntry{
+		{TabTitle: "BUILD", HandlerName: "A", HandlerType: HandlerTypeEdit},
+		{TabTitle: "BUILD", HandlerName: "B", HandlerType: HandlerTypeEdit},
+		{TabTitle: "OTHER", HandlerName: "C", HandlerType: HandlerTypeEdit},
+	})
+	section.IndexActiveEditField = 1
+	tui.editModeActivated = true
+
+	tui.primaryDaemon().reconcileRemoteHandlers([]StateEntry{{TabTitle: "BUILD", HandlerName: "A", HandlerType: HandlerTypeEdit}})
+	if section.IndexActiveEditField != 0 || tui.editModeActivated {
+		t.Errorf("removing the focused field should clamp the focus and leave edit mode, got %d %v",
+			section.IndexActiveEditField, tui.editModeActivated)
+	}
+	if other := tui.tabByTitle("OTHER"); len(other.FieldHandlers) != 0 {
+		t.Error("remote fields of tabs absent from the snapshot should be removed")
+	}
+}
@@ -0,0 +1,194 @@
+package devtui
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Client mode tabs: a daemon tab with no matching local NewTabSection is
+// created on the fly (tabSection.remote) from StateEntry.TabTitle, a log line's
+// TabTitle or an "event: tabs" descriptor list, so a generic client can attach
+// to any daemon. Remote tabs the daemon no longer reports are removed; local
+// tabs are never touched. Tabs are created, removed and reordered on the UI
+// goroutine (see runOnUI).
+
+// tabSource is what created a remote tab. A state snapshot only removes the
+// tabs it created; an "event: tabs" list is authoritative and removes any
+// remote tab of its daemon it does not list.
+type tabSource int
+
+const (
+	tabFromState tabSource = iota // StateEntry.TabTitle
+	tabFromLog                    // a log line's TabTitle
+	tabFromList                   // an "event: tabs" descriptor
+)
+
+// tabByTitle returns the tab titled title, nil if none.
+func (h *DevTUI) tabByTitle(title string) *tabSection {
+	for _, s := range h.TabSections {
+		if s.Title == title {
+			return s
+		}
+	}
+	return nil
+}
+
+// remoteTab returns the tab showing the daemon tab titled title, creating a
+// remote tab from source in client mode. Returns nil outside client mode when
+// no local tab matches.
+func (d *remoteDaemon) remoteTab(title, description string, source tabSource) *tabSection {
+	h := d.tui
+	title = d.tabTitle(title)
+	if section := h.tabByTitle(title); section != nil {
+		return section
+	}
+	if !h.ClientMode || title == "" {
+		return nil
+	}
+	section := h.NewTabSection(title, description).(*tabSection)
+	section.remote = true
+	section.daemon = d
+	section.source = source
+	return section
+}
+
+// logTab returns the tab of a log line's daemon tab title. Tabs already found
+// are kept in d.logTabs, so only the first line of a tab waits on the UI
+// goroutine (to find or create it) instead of every line.
+func (d *remoteDaemon) logTab(title string) *tabSection {
+	d.logTabsMu.Lock()
+	section := d.logTabs[title]
+	d.logTabsMu.Unlock()
+	if section != nil && !section.removed.Load() {
+		return section
+	}
+
+	d.tui.runOnUI(func() { section = d.remoteTab(title, "", tabFromLog) })
+	if section != nil {
+		d.logTabsMu.Lock()
+		if d.logTabs == nil {
+			d.logTabs = make(map[string]*tabSection)
+		}
+		d.logTabs[title] = section
+		d.logTabsMu.Unlock()
+	}
+	return section
+}
+
+// removeRemoteTabs removes the remote tabs whose title is not in keep.
+func (h *DevTUI) removeRemoteTabs(keep map[string]bool) {
+	previous := slices.Clone(h.TabSections)
+	h.TabSections = slices.DeleteFunc(h.TabSections, func(s *tabSection) bool {
+		if !s.remote || keep[s.Title] {
+			return false
+		}
+		s.stopAllAnimations()
+		s.removed.Store(true) // log lines look it up again (see logTab)
+		return true
+	})
+	if len(h.TabSections) != len(previous) {
+		h.reindexTabs(previous)
+	}
+}
+
+// reindexTabs renumbers the tabs after tabs were removed or reordered:
+// tabSection.Index, the shortcut TabIndex values and the active tab follow
+// their tab; shortcuts of removed tabs are dropped.
+func (h *DevTUI) reindexTabs(previous []*tabSection) {
+	moved := make(map[int]int, len(previous)) // old index -> new index (-1 = removed)
+	for old, s := range previous {
+		moved[old] = slices.Index(h.TabSections, s)
+	}
+	for i, s := range h.TabSections {
+		s.Index = i
+	}
+	if h.shortcutRegistry != nil {
+		h.shortcutRegistry.reindexTabs(moved)
+	}
+	if active, ok := moved[h.activeTab]; ok && active >= 0 {
+		h.activeTab = active
+	} else {
+		h.activeTab = max(0, min(h.activeTab, len(h.TabSections)-1))
+		h.copyMode = nil // the selection belonged to the removed tab
+	}
+}
+
+// handleTabsEvent applies an "event: tabs" descriptor list: creates missing
+// remote tabs, updates their descriptions, orders them and removes the rest.
+func (d *remoteDaemon) handleTabsEvent(data string) {
+	h := d.tui
+	var tabs []TabDescriptor
+	if err := json.Unmarshal([]byte(data), &tabs); err != nil {
+		if !h.isShuttingDown.Load() && h.Logger != nil {
+			h.Logger("Error unmarshalling SSE tabs:", err)
+		}
+		return
+	}
+	slices.SortStableFunc(tabs, func(a, b TabDescriptor) int { return a.Order - b.Order })
+
+	h.runOnUI(func() {
+		keep := make(map[string]bool, len(tabs))
+		order := make(map[string]int, len(tabs))
+		for i, t := range tabs {
+			title := d.tabTitle(t.Title)
+			keep[title] = true
+			order[title] = i
+			if section := d.remoteTab(t.Title, t.Description, tabFromList); section != nil && section.remote {
+				section.SectionDescription = t.Description
+				section.source = tabFromList // listed tabs outlive the state entries
+			}
+		}
+		d.syncTabs(keep, tabFromList)
+		h.orderRemoteTabs(order)
+	})
+}
+
+// orderRemoteTabs sorts the remote tabs listed in order among the slots they
+// occupy; local tabs and the other daemons' tabs keep their position.
+func (h *DevTUI) orderRemoteTabs(order map[string]int) {
+	var slots []int
+	var remote []*tabSection
+	for i, s := range h.TabSections {
+		if _, listed := order[s.Title]; s.remote && listed {
+			slots = append(slots, i)
+			remote = append(remote, s)
+		}
+	}
+	slices.SortStableFunc(remote, func(a, b *tabSection) int { return order[a.Title] - order[b.Title] })
+
+	previous := slices.Clone(h.TabSections)
+	for i, slot := range slots {
+		h.TabSections[slot] = remote[i]
+	}
+	if !slices.Equal(previous, h.TabSections) {
+		h.reindexTabs(previous)
+	}
+}
+
+// stopAllAnimations stops every LogOpen/progress animation of the tab.
+func (ts *tabSection) stopAllAnimations() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for name, stop := range ts.animationStopChans {
+		close(stop)
+		delete(ts.animationStopChans, name)
+	}
+}
+
+// reindexTabs moves shortcut entries to their tab's new index (see
+// DevTUI.reindexTabs) and drops the entries of removed tabs.
+func (sr *ShortcutRegistry) reindexTabs(moved map[int]int) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.entries = slices.DeleteFunc(sr.entries, func(e *ShortcutEntry) bool {
+		index, ok := moved[e.TabIndex]
+		if !ok {
+			return false
+		}
+		if index < 0 {
+			return true
+		}
+		e.TabIndex = index
+		return false
+	})
+}
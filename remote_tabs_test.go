@@ -0,0 +1,210 @@
+package devtui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func tabTitles(tui *DevTUI) string {
+	var titles []string
+	for i, s := range tui.TabSections {
+		if s.Index != i {
+			titles = append(titles, "!index")
+		}
+		titles = append(titles, s.Title)
+	}
+	return strings.Join(titles, ",")
+}
+
+func TestRemoteTabs_CreatedFromStateAndLogs(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	tui.NewTabSection("LOCAL", "")
+
+	tui.primaryDaemon().applyState([]StateEntry{
+		{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit, Label: "Port", Value: "8080", Shortcut: "Port"},
+		{TabTitle: "DEPLOY", HandlerName: "Deployer", HandlerType: HandlerTypeLoggable},
+	})
+	if got := tabTitles(tui); got != "LOCAL,BUILD,DEPLOY" {
+		t.Fatalf("state entries should create remote tabs, got %s", got)
+	}
+	if len(tui.tabByTitle("BUILD").FieldHandlers) != 1 {
+		t.Error("remote field should be added to the created tab")
+	}
+
+	data, _ := json.Marshal(tabContentDTO{Id: "1", Timestamp: "1", Content: "hello", TabTitle: "TESTS", HandlerName: "Runner", HandlerType: handlerTypeLoggable})
+	tui.handleLogEvent(string(data))
+	if section := tui.tabByTitle("TESTS"); section == nil || len(section.contentsSnapshot()) != 1 {
+		t.Fatal("a log line for an unknown tab should create it")
+	}
+
+	local := NewTUI(&TuiConfig{})
+	newRemoteDaemon(local, Endpoint{}).reconcileRemoteHandlers([]StateEntry{{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit}})
+	if len(local.TabSections) != 0 {
+		t.Error("tabs are only created in client mode")
+	}
+}
+
+func TestRemoteTabs_TabsEventOrdersAndRemoves(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	tui.NewTabSection("LOCAL", "")
+	tui.primaryDaemon().handleTabsEvent(`[{"title":"A","order":2},{"title":"B","description":"Backend","order":1},{"title":"C","order":3}]`)
+	if got := tabTitles(tui); got != "LOCAL,B,A,C" {
+		t.Fatalf("tabs should be created in order, got %s", got)
+	}
+	if tui.tabByTitle("B").SectionDescription != "Backend" {
+		t.Error("description should come from the descriptor")
+	}
+
+	// A shortcut of tab C must follow it; the active tab too
+	c := tui.tabByTitle("C")
+	tui.shortcutRegistry.Register("x", &ShortcutEntry{TabIndex: c.Index, HandlerName: "X", Remote: true})
+	tui.shortcutRegistry.Register("y", &ShortcutEntry{TabIndex: tui.tabByTitle("A").Index, HandlerName: "Y", Remote: true})
+	tui.activeTab = c.Index
+
+	tui.primaryDaemon().handleTabsEvent(`[{"title":"C","order":1}]`)
+	if got := tabTitles(tui); got != "LOCAL,C" {
+		t.Fatalf("tabs missing from the list should be removed, got %s", got)
+	}
+	if tui.activeTab != 1 {
+		t.Errorf("active tab should follow C, got %d", tui.activeTab)
+	}
+	if e, ok := tui.shortcutRegistry.Get("x"); !ok || e.TabIndex != 1 {
+		t.Errorf("shortcut of C should be reindexed, got %+v", e)
+	}
+	if _, ok := tui.shortcutRegistry.Get("y"); ok {
+		t.Error("shortcuts of removed tabs should be dropped")
+	}
+
+	tui.removeRemoteTabs(map[string]bool{})
+	if got := tabTitles(tui); got != "LOCAL" {
+		t.Errorf("local tabs are never removed, got %s", got)
+	}
+}
+
+// startTestProgram runs the tea program of tui without a terminal until the
+// returned stop function quits it.
+func startTestProgram(t *testing.T, tui *DevTUI) (stop func()) {
+	t.Helper()
+	tui.tea = tea.NewProgram(tui, tea.WithInput(nil), tea.WithoutRenderer(), tea.WithoutSignalHandler())
+	stopped := make(chan struct{})
+	go func() {
+		tui.runProgram()
+		close(stopped)
+	}()
+	waitFor(t, "the program to start", tui.uiRunning.Load)
+	return func() {
+		tui.tea.Quit()
+		<-stopped
+	}
+}
+
+func TestRemoteTabs_ChangedOnTheUIGoroutine(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	stop := startTestProgram(t, tui)
+
+	// Calls are run one at a time by the event loop: while one holds it, the
+	// next one waits for it instead of running on its own goroutine
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go tui.runOnUI(func() {
+		close(held)
+		<-release
+	})
+	<-held
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	afterRelease := false
+	tui.runOnUI(func() {
+		select {
+		case <-release:
+			afterRelease = true
+		default:
+		}
+	})
+	if !afterRelease {
+		t.Error("runOnUI should wait for the event loop")
+	}
+
+	tui.primaryDaemon().handleTabsEvent(`[{"title":"BUILD","order":0}]`)
+	var titles string
+	tui.runOnUI(func() { titles = tabTitles(tui) })
+	if titles != "BUILD" {
+		t.Errorf("the tabs event should create the tab, got %s", titles)
+	}
+
+	stop()
+	ran := false
+	tui.runOnUI(func() { ran = true })
+	if !ran {
+		t.Error("after quit, runOnUI should run on the calling goroutine")
+	}
+}
+
+func TestRemoteTabs_StateOnlyRemovesItsOwnTabs(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	d := tui.primaryDaemon()
+
+	d.applyState([]StateEntry{{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit}})
+	data, _ := json.Marshal(tabContentDTO{Id: "1", Timestamp: "1", Content: "hello", TabTitle: "LOGS", HandlerName: "Srv", HandlerType: handlerTypeLoggable})
+	d.handleLogEvent(string(data))
+	d.handleTabsEvent(`[{"title":"BUILD","order":0},{"title":"LOGS","order":1},{"title":"DOCS","order":2}]`)
+	if got := tabTitles(tui); got != "BUILD,LOGS,DOCS" {
+		t.Fatalf("expected the state, log and listed tabs, got %s", got)
+	}
+
+	d.applyState([]StateEntry{{TabTitle: "TEST", HandlerName: "Run", HandlerType: HandlerTypeExecution}})
+	if got := tabTitles(tui); got != "BUILD,LOGS,DOCS,TEST" {
+		t.Fatalf("a snapshot should not remove tabs created by log lines or listed, got %s", got)
+	}
+	d.applyState([]StateEntry{{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit}})
+	if got := tabTitles(tui); got != "BUILD,LOGS,DOCS" {
+		t.Errorf("a snapshot should remove the tabs a snapshot created, got %s", got)
+	}
+
+	d.handleTabsEvent(`[{"title":"BUILD","order":0}]`)
+	if got := tabTitles(tui); got != "BUILD" {
+		t.Errorf("a tabs list removes every tab it does not list, got %s", got)
+	}
+}
+
+func TestRemoteTabs_LogLinesOnlyWaitForNewTabs(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	d := tui.primaryDaemon()
+	line := func(id string) string {
+		data, _ := json.Marshal(tabContentDTO{Id: id, Timestamp: id, Content: id, TabTitle: "LOGS", HandlerName: "Srv", HandlerType: handlerTypeLoggable})
+		return string(data)
+	}
+	stop := startTestProgram(t, tui)
+	defer stop()
+	d.handleLogEvent(line("1")) // creates the tab on the UI goroutine
+
+	// With the event loop held, a line for a known tab doesn't wait for it
+	release, held := make(chan struct{}), make(chan struct{})
+	go tui.runOnUI(func() {
+		close(held)
+		<-release
+	})
+	<-held
+	time.AfterFunc(500*time.Millisecond, func() { close(release) })
+	d.handleLogEvent(line("2"))
+	select {
+	case <-release:
+		t.Error("a line for a known tab should not wait for the UI goroutine")
+	default:
+	}
+
+	var section *tabSection
+	tui.runOnUI(func() {
+		section = tui.tabByTitle("LOGS")
+		tui.removeRemoteTabs(map[string]bool{})
+	})
+	d.handleLogEvent(line("3"))
+	var recreated *tabSection
+	tui.runOnUI(func() { recreated = tui.tabByTitle("LOGS") })
+	if recreated == nil || recreated == section {
+		t.Error("a line for a removed tab should create it again")
+	}
+}
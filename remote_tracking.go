@@ -0,0 +1,77 @@
+package devtui
+
+import "slices"
+
+// Remote logs follow the tracking rules of local ones (see updateOrAddContent):
+// a daemon line replaces the line it tracks instead of being appended.
+//
+//   - same Id: the daemon updated a tracked line (Ids are immutable across updates)
+//   - same OperationID: a later step of the same async operation
+//   - IsProgress/IsComplete without OperationID: the handler's tracked line
+//
+// IsProgress lines animate client side like LogOpen; IsComplete stops the animation.
+
+// remoteTrackIndex returns the index of the line tracked by content, -1 if none.
+// Must be called with ts.mu held.
+func (ts *tabSection) remoteTrackIndex(content tabContent) int {
+	return slices.IndexFunc(ts.tabContents, func(tc tabContent) bool {
+		switch {
+		case content.Id != "" && tc.Id == content.Id:
+			return true
+		case content.operationID != nil:
+			return tc.operationID != nil && *tc.operationID == *content.operationID
+		case content.isProgress || content.isComplete:
+			return tc.RawHandlerName == content.RawHandlerName && (tc.isProgress || tc.isComplete)
+		}
+		return false
+	})
+}
+
+// applyRemoteContent adds a daemon line, replacing the line it tracks.
+// Returns the stored line (which keeps the Id of the replaced one).
+func (ts *tabSection) applyRemoteContent(content tabContent) tabContent {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if i := ts.remoteTrackIndex(content); i >= 0 {
+		previous := ts.tabContents[i]
+		ts.recordHistory(previous)
+		content.Id = previous.Id
+		ts.tabContents = append(ts.tabContents[:i], ts.tabContents[i+1:]...)
+	} else if !slices.Contains(ts.groupOrder, content.RawHandlerName) {
+		ts.groupOrder = append(ts.groupOrder, content.RawHandlerName)
+	}
+
+	ts.tabContents = append(ts.tabContents, content)
+	ts.trimContents()
+	return content
+}
+
+// applyAnimationFrame replaces the content of the line with the given Id with
+// an animation tick, in place. Returns false when the line is gone.
+func (ts *tabSection) applyAnimationFrame(id, content string) (tabContent, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	i := slices.IndexFunc(ts.tabContents, func(tc tabContent) bool { return tc.Id == id })
+	if i < 0 {
+		return tabContent{}, false
+	}
+	ts.tabContents[i].Content = content
+	ts.tabContents[i].animationFrame = true
+	return ts.tabContents[i], true
+}
+
+// animateRemoteContent mirrors LogOpen/LogClose for a daemon line, stored as
+// content: its animation updates that line by Id, not the handler's first line.
+func (ts *tabSection) animateRemoteContent(content tabContent) {
+	switch {
+	case content.isComplete:
+		ts.stopAnimation(content.RawHandlerName)
+	case content.isProgress:
+		ts.animate(content.RawHandlerName, content.Content, func(frame string) {
+			ts.tui.sendRemoteAnimationFrame(ts, content.Id, frame)
+		})
+	default:
+		ts.stopAnimation(content.RawHandlerName)
+	}
+}
@@ -0,0 +1,95 @@
+package devtui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sendRemoteLog(t *testing.T, tui *DevTUI, dto tabContentDTO) {
+	t.Helper()
+	dto.TabTitle = "BUILD"
+	if dto.HandlerType == 0 {
+		dto.HandlerType = handlerTypeLoggable
+	}
+	data, err := json.Marshal(dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tui.handleLogEvent(string(data))
+}
+
+func TestRemoteLogs_TrackedById(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+
+	sendRemoteLog(t, tui, tabContentDTO{Id: "1", Timestamp: "1", Content: "Compiling", HandlerName: "Compiler"})
+	sendRemoteLog(t, tui, tabContentDTO{Id: "2", Timestamp: "2", Content: "Server up", HandlerName: "Server"})
+	sendRemoteLog(t, tui, tabContentDTO{Id: "1", Timestamp: "3", Content: "Compiled", HandlerName: "Compiler"})
+
+	contents := section.contentsSnapshot()
+	if len(contents) != 2 {
+		t.Fatalf("an update of a tracked line should replace it, got %d lines", len(contents))
+	}
+	if contents[1].Content != "Compiled" || contents[1].Id != "1" {
+		t.Errorf("updated line should move to the end, got %+v", contents[1])
+	}
+	if versions, _ := section.historyOf("1"); len(versions) != 1 || versions[0].Content != "Compiling" {
+		t.Errorf("replaced version should be kept in the history, got %+v", versions)
+	}
+}
+
+func TestRemoteLogs_ProgressAnimatesItsOwnLine(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+
+	sendRemoteLog(t, tui, tabContentDTO{Id: "1", Timestamp: "1", Content: "listening", HandlerName: "Deployer"})
+	sendRemoteLog(t, tui, tabContentDTO{Id: "2", Timestamp: "2", Content: "Deploying", HandlerName: "Deployer", IsProgress: true})
+	defer section.stopAnimation("Deployer")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for section.contentsSnapshot()[1].Content == "Deploying" {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for an animation frame")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	contents := section.contentsSnapshot()
+	if contents[0].Content != "listening" || !strings.HasPrefix(contents[1].Content, "Deploying .") || contents[1].Id != "2" {
+		t.Errorf("frames should update the progress line only, got %q / %q", contents[0].Content, contents[1].Content)
+	}
+}
+
+func TestRemoteLogs_ProgressAndOperations(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	section := tui.NewTabSection("BUILD", "").(*tabSection)
+	op := "deploy-42"
+
+	sendRemoteLog(t, tui, tabContentDTO{Id: "1", Timestamp: "1", Content: "Deploying", HandlerName: "Deployer", IsProgress: true})
+	section.mu.RLock()
+	animating := section.animationStopChans["Deployer"] != nil
+	section.mu.RUnlock()
+	if !animating {
+		t.Error("a progress line should animate like LogOpen")
+	}
+	sendRemoteLog(t, tui, tabContentDTO{Id: "2", Timestamp: "2", Content: "Deployed", HandlerName: "Deployer", IsComplete: true})
+	section.mu.RLock()
+	animating = section.animationStopChans["Deployer"] != nil
+	section.mu.RUnlock()
+	if animating {
+		t.Error("a complete line should stop the animation")
+	}
+
+	sendRemoteLog(t, tui, tabContentDTO{Id: "3", Timestamp: "3", Content: "step 1/2", HandlerName: "Worker", OperationID: &op})
+	sendRemoteLog(t, tui, tabContentDTO{Id: "4", Timestamp: "4", Content: "plain line", HandlerName: "Worker"})
+	sendRemoteLog(t, tui, tabContentDTO{Id: "5", Timestamp: "5", Content: "step 2/2", HandlerName: "Worker", OperationID: &op})
+
+	var lines []string
+	for _, c := range section.contentsSnapshot() {
+		lines = append(lines, c.Content)
+	}
+	if got := strings.Join(lines, "|"); got != "Deployed|plain line|step 2/2" {
+		t.Errorf("expected one line per progress handler and operation, got %q", got)
+	}
+}
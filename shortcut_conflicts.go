@@ -0,0 +1,98 @@
+package devtui
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// ShortcutConflictPolicy decides what ShortcutRegistry.Register does when a key
+// is already taken by another handler (local or remote).
+type ShortcutConflictPolicy int
+
+const (
+	ShortcutLastWins      ShortcutConflictPolicy = iota // the new registration replaces the previous one (default)
+	ShortcutFirstWins                                   // the previous registration is kept, the new one is dropped
+	ShortcutConflictError                               // the previous registration is kept and Register returns an error
+)
+
+// ShortcutConflict records two handlers claiming the same key.
+type ShortcutConflict struct {
+	Key      string         // normalized key spec
+	Existing ShortcutEntry  // registration that owned the key
+	Incoming ShortcutEntry  // registration that claimed it afterwards
+	Winner   *ShortcutEntry // entry bound to the key after applying the policy (Existing or Incoming)
+}
+
+// String describes the conflict, e.g. `"r": Builder (BUILD) vs Runner (remote)`.
+func (c ShortcutConflict) String() string {
+	return Sprintf("'%s': %s vs %s, %s wins", c.Key, describeShortcutOwner(c.Existing), describeShortcutOwner(c.Incoming), c.Winner.HandlerName)
+}
+
+func describeShortcutOwner(e ShortcutEntry) string {
+	if e.Remote {
+		return e.HandlerName + " (remote)"
+	}
+	return e.HandlerName
+}
+
+// isConflict reports whether incoming claims a key owned by a different handler.
+// A handler re-registering its own key (e.g. remote state rebuilt) is not a conflict;
+// same-named handlers of two daemons are different handlers.
+func isConflict(existing, incoming *ShortcutEntry) bool {
+	return existing.HandlerName != incoming.HandlerName || existing.Remote != incoming.Remote || existing.Daemon != incoming.Daemon
+}
+
+// resolveConflict records a conflict on key, once per pair of owners, and applies
+// the policy. Returns whether incoming must be stored, the message to log for a
+// new conflict ("" = none) and the error to return (ShortcutConflictError).
+// Must be called with sr.mu held; the caller logs after releasing it.
+func (sr *ShortcutRegistry) resolveConflict(key string, existing, incoming *ShortcutEntry) (replace bool, report string, err error) {
+	conflict := ShortcutConflict{Key: key, Existing: *existing, Incoming: *incoming, Winner: existing}
+	replace = sr.policy == ShortcutLastWins
+	if replace {
+		conflict.Winner = incoming
+	}
+	recorded := false
+	for i, c := range sr.conflicts {
+		if c.Key == key && !isConflict(&c.Existing, existing) && !isConflict(&c.Incoming, incoming) {
+			sr.conflicts[i], recorded = conflict, true
+			break
+		}
+	}
+	if !recorded {
+		sr.conflicts = append(sr.conflicts, conflict)
+	}
+
+	if sr.policy == ShortcutConflictError {
+		return false, "", Err("shortcut conflict " + conflict.String())
+	}
+	if !recorded {
+		report = "Shortcut conflict " + conflict.String()
+	}
+	return replace, report, nil
+}
+
+// Conflicts returns every shortcut conflict detected so far, in detection order.
+func (sr *ShortcutRegistry) Conflicts() []ShortcutConflict {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return append([]ShortcutConflict(nil), sr.conflicts...)
+}
+
+// conflictsFor returns the conflicts recorded on a normalized key.
+func (sr *ShortcutRegistry) conflictsFor(key string) []ShortcutConflict {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	var out []ShortcutConflict
+	for _, c := range sr.conflicts {
+		if c.Key == key {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ShortcutConflicts returns every shortcut conflict detected while registering
+// handlers (local and remote). Startup checks can fail when it is not empty.
+func (h *DevTUI) ShortcutConflicts() []ShortcutConflict {
+	return h.shortcutRegistry.Conflicts()
+}
@@ -0,0 +1,83 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortcutConflicts_Policies(t *testing.T) {
+	tests := []struct {
+		policy     ShortcutConflictPolicy
+		wantWinner string
+		wantErr    bool
+	}{
+		{ShortcutLastWins, "Runner", false},
+		{ShortcutFirstWins, "Builder", false},
+		{ShortcutConflictError, "Builder", true},
+	}
+	for _, tt := range tests {
+		var logged []string
+		sr := newShortcutRegistry()
+		sr.policy = tt.policy
+		sr.logger = func(messages ...any) { logged = append(logged, messages[0].(string)) }
+
+		if err := sr.TryRegister("r", &ShortcutEntry{HandlerName: "Builder"}); err != nil {
+			t.Fatalf("policy %d: unexpected error %v", tt.policy, err)
+		}
+		err := sr.TryRegister("r", &ShortcutEntry{HandlerName: "Runner", Remote: true})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("policy %d: expected error=%v, got %v", tt.policy, tt.wantErr, err)
+		}
+		if tt.wantErr == (len(logged) > 0) {
+			t.Errorf("policy %d: conflicts resolved without error must be logged (logged=%v)", tt.policy, logged)
+		}
+		if entry, _ := sr.Get("r"); entry.HandlerName != tt.wantWinner {
+			t.Errorf("policy %d: expected %s to own \"r\", got %s", tt.policy, tt.wantWinner, entry.HandlerName)
+		}
+
+		conflicts := sr.Conflicts()
+		if len(conflicts) != 1 || conflicts[0].Existing.HandlerName != "Builder" || !conflicts[0].Incoming.Remote {
+			t.Errorf("policy %d: expected one Builder vs remote Runner conflict, got %+v", tt.policy, conflicts)
+		}
+	}
+}
+
+func TestShortcutConflicts_SameHandlerIsNotAConflict(t *testing.T) {
+	sr := newShortcutRegistry()
+	sr.Register("w", &ShortcutEntry{HandlerName: "WASM", Remote: true})
+	sr.Register("w", &ShortcutEntry{HandlerName: "WASM", Remote: true})
+	if len(sr.Conflicts()) != 0 {
+		t.Errorf("re-registering the same handler must not be a conflict: %+v", sr.Conflicts())
+	}
+}
+
+func TestShortcutConflicts_RecordedOncePerOwners(t *testing.T) {
+	sr := newShortcutRegistry()
+	sr.policy = ShortcutFirstWins
+	logged := 0
+	// The logger may use the registry: it is called with the lock released
+	sr.logger = func(messages ...any) { logged += len(sr.Conflicts()) }
+
+	sr.Register("r", &ShortcutEntry{HandlerName: "Builder"})
+	for i := 0; i < 3; i++ { // e.g. the same remote state received again
+		sr.Register("r", &ShortcutEntry{HandlerName: "Runner", Remote: true})
+	}
+	if n := len(sr.Conflicts()); n != 1 || logged != 1 {
+		t.Errorf("a repeated conflict should be recorded and logged once, got %d recorded, %d logged", n, logged)
+	}
+}
+
+func TestShortcutConflicts_MarkedInHelp(t *testing.T) {
+	h := DefaultTUIForTest()
+	tab := h.NewTabSection("BUILD", "Build")
+	h.AddHandler(&testModeHandler{TestEditableHandler: NewTestEditableHandler("A", ""), shortcuts: []map[string]string{{"r": "rebuild"}}}, "", tab)
+	h.AddHandler(&testModeHandler{TestEditableHandler: NewTestEditableHandler("B", ""), shortcuts: []map[string]string{{"r": "run"}}}, "", tab)
+
+	if len(h.ShortcutConflicts()) != 1 {
+		t.Fatalf("expected 1 conflict, got %d", len(h.ShortcutConflicts()))
+	}
+	help := (&shortcutsInteractiveHandler{tui: h}).generateHelpContent()
+	if !strings.Contains(help, "conflict") {
+		t.Errorf("help should mark the conflicting key, got:\n%s", help)
+	}
+}
@@ -0,0 +1,64 @@
+package devtui
+
+// ShortcutScope limits where a shortcut is active. When the same key is
+// registered in several scopes, the narrowest active one runs.
+type ShortcutScope int
+
+const (
+	ScopeGlobal ShortcutScope = iota // active from any tab (default, ShortcutProvider)
+	ScopeTab                         // active only while the handler's tab is shown
+	ScopeField                       // active only while the handler's field is selected
+)
+
+// String returns "global", "tab" or "field".
+func (s ShortcutScope) String() string {
+	switch s {
+	case ScopeTab:
+		return "tab"
+	case ScopeField:
+		return "field"
+	default:
+		return "global"
+	}
+}
+
+// ScopedShortcut is one entry of ScopedShortcutProvider.ScopedShortcuts().
+type ScopedShortcut struct {
+	Key         string        // key spec: "t", "ctrl+b", "g d"
+	Description string        // shown in the SHORTCUTS help and the command palette
+	Scope       ShortcutScope // ScopeGlobal, ScopeTab or ScopeField
+}
+
+// activeIn reports whether the entry can run while tabIndex/fieldIndex is active.
+func (e *ShortcutEntry) activeIn(tabIndex, fieldIndex int) bool {
+	switch e.Scope {
+	case ScopeTab:
+		return e.TabIndex == tabIndex
+	case ScopeField:
+		return e.TabIndex == tabIndex && e.FieldIndex == fieldIndex
+	default:
+		return true
+	}
+}
+
+// activeTogether reports whether a and b can be active at the same time, i.e.
+// in some tab and field both are active in.
+func activeTogether(a, b *ShortcutEntry) bool {
+	return a.activeIn(b.TabIndex, b.FieldIndex) || b.activeIn(a.TabIndex, a.FieldIndex)
+}
+
+// sameShortcutSlot reports whether a and b bind the same key in the same scope,
+// i.e. registering b after a is a replacement (or a conflict).
+func sameShortcutSlot(a, b *ShortcutEntry) bool {
+	if a.Key != b.Key || a.Scope != b.Scope {
+		return false
+	}
+	switch a.Scope {
+	case ScopeTab:
+		return a.TabIndex == b.TabIndex
+	case ScopeField:
+		return a.TabIndex == b.TabIndex && a.FieldIndex == b.FieldIndex
+	default:
+		return true
+	}
+}
@@ -0,0 +1,85 @@
+package devtui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testScopedHandler is an editable handler exposing scoped shortcuts.
+type testScopedHandler struct {
+	*TestEditableHandler
+	shortcuts []ScopedShortcut
+}
+
+func (h *testScopedHandler) ScopedShortcuts() []ScopedShortcut { return h.shortcuts }
+
+func TestScopedShortcuts_SameKeyPerTab(t *testing.T) {
+	h := DefaultTUIForTest()
+	logs := h.NewTabSection("LOGS", "Logs")
+	tests := h.NewTabSection("TESTS", "Tests")
+	build := h.NewTabSection("BUILD", "Build")
+
+	tester := &testScopedHandler{TestEditableHandler: NewTestEditableHandler("Tester", ""), shortcuts: []ScopedShortcut{{Key: "t", Description: "run tests", Scope: ScopeTab}}}
+	builder := &testScopedHandler{TestEditableHandler: NewTestEditableHandler("Builder", ""), shortcuts: []ScopedShortcut{{Key: "t", Description: "build target", Scope: ScopeTab}}}
+	h.AddHandler(tester, "", tests)
+	h.AddHandler(builder, "", build)
+
+	if len(h.ShortcutConflicts()) != 0 {
+		t.Fatalf("tab scoped keys in different tabs must not conflict: %+v", h.ShortcutConflicts())
+	}
+
+	// In LOGS "t" is not active: the key is not consumed and the tab does not change
+	h.activeTab = logs.(*tabSection).Index
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if h.activeTab != logs.(*tabSection).Index || tester.Value() != "" || builder.Value() != "" {
+		t.Errorf("tab scoped shortcut must not run outside its tab (tab=%d)", h.activeTab)
+	}
+
+	h.activeTab = build.(*tabSection).Index
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if builder.Value() != "t" || tester.Value() != "" {
+		t.Errorf("\"t\" in BUILD should run the builder shortcut (builder=%q tester=%q)", builder.Value(), tester.Value())
+	}
+}
+
+func TestScopedShortcuts_Precedence(t *testing.T) {
+	sr := newShortcutRegistry()
+	sr.Register("r", &ShortcutEntry{HandlerName: "Global", Scope: ScopeGlobal})
+	sr.Register("r", &ShortcutEntry{HandlerName: "Tab", Scope: ScopeTab, TabIndex: 1})
+	sr.Register("r", &ShortcutEntry{HandlerName: "Field", Scope: ScopeField, TabIndex: 1, FieldIndex: 2})
+
+	cases := []struct {
+		tab, field int
+		want       string
+	}{
+		{0, 0, "Global"},
+		{1, 0, "Tab"},
+		{1, 2, "Field"},
+	}
+	for _, c := range cases {
+		entry, ok := sr.GetInScope("r", c.tab, c.field)
+		if !ok || entry.HandlerName != c.want {
+			t.Errorf("tab %d field %d: expected %s, got %+v", c.tab, c.field, c.want, entry)
+		}
+	}
+	if entry, _ := sr.Get("r"); entry.HandlerName != "Global" {
+		t.Errorf("Get should return the global entry, got %s", entry.HandlerName)
+	}
+}
+
+func TestScopedShortcuts_ChordsOfOtherTabs(t *testing.T) {
+	sr := newShortcutRegistry()
+	if err := sr.TryRegister("g d", &ShortcutEntry{HandlerName: "Deployer", Scope: ScopeTab, TabIndex: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sr.TryRegister("g", &ShortcutEntry{HandlerName: "Git", Scope: ScopeTab, TabIndex: 1}); err != nil {
+		t.Errorf("chords of tabs never shown together are not ambiguous: %v", err)
+	}
+	if err := sr.TryRegister("g", &ShortcutEntry{HandlerName: "Go", Scope: ScopeField, TabIndex: 2, FieldIndex: 0}); err == nil {
+		t.Error("a field of the chord's tab should be ambiguous with it")
+	}
+	if err := sr.TryRegister("g", &ShortcutEntry{HandlerName: "Grep", Scope: ScopeGlobal}); err == nil {
+		t.Error("a global key should be ambiguous with every chord")
+	}
+}
@@ -2,6 +2,8 @@ package devtui
 
 // createShortcutsTab creates and registers the shortcuts tab with its handler
 import (
+	"slices"
+
 	. "github.com/tinywasm/fmt"
 	"github.com/tinywasm/fmt/lang"
 )
@@ -74,38 +76,26 @@ func (h *shortcutsInteractiveHandler) WaitingForUser() bool {
 
 // generateHelpContent creates the help content string
 func (h *shortcutsInteractiveHandler) generateHelpContent() string {
-	content := lang.Translate(h.appName, "shortcuts", "keyboard", `:`+"\n\n",
-		"content", "tab", `:
-  • Tab/Shift+Tab  -`, "switch", "content", "\n\n",
-		"fields", `:
-  • `, "arrow", "left", `/`, "right", `     -`, "switch", "field", `
-  • Enter          				-`, "edit", `/`, "execute", `
-  • Esc            				-`, "cancel", "\n\n",
-		"edit", "text", `:
+	content := lang.Translate(h.appName, "shortcuts", "keyboard", `:`).String() + "\n\n" +
+		Convert(h.keyBindingLines()).Join("\n").String() + "\n\n"
+	content += lang.Translate("edit", "text", `:
   • `, "arrow", "left", `/`, "right", `   -`, "move", `cursor
   • Backspace      			-`, "create", "space", `
+  • Esc            				-`, "cancel", `
 
 Viewport:
-  • `, "arrow", "up", "/", "down", `    - Scroll`, "line", "text", `
-  • PgUp/PgDown    		- Scroll`, "page", `
   • Mouse Wheel    		- Scroll`, "page", "\n\n",
 		`Scroll `, "status", "icons", `:
   •  ■  - `, "all", "content", "visible", `
   •  ▼  - `, "can", `scroll`, "down", `
   •  ▲  - `, "can", `scroll`, "up", `
-  • ▼ ▲ - `, "can", `scroll`, "down", `/`, "up", "\n\n",
-		"quit", `:
-  • Ctrl+C         - `, "quit", `
-`).String()
+  • ▼ ▲ - `, "can", `scroll`, "down", `/`, "up", "\n",
+	).String()
 
 	// Add registered shortcuts section
 	if h.tui != nil && h.tui.shortcutRegistry != nil {
-		shortcuts := h.getRegisteredShortcuts()
-		if len(shortcuts) > 0 {
-			content += "\n\nRegistered Shortcuts:\n"
-			for key, description := range shortcuts {
-				content += Sprintf("  • %s - %s\n", key, description)
-			}
+		if lines := h.registeredShortcutLines(); len(lines) > 0 {
+			content += "\n\nRegistered Shortcuts:\n" + Convert(lines).Join("\n").String() + "\n"
 		}
 	}
 
@@ -113,14 +103,88 @@ Viewport:
 	return content
 }
 
-// getRegisteredShortcuts returns all registered shortcuts with descriptions
-func (h *shortcutsInteractiveHandler) getRegisteredShortcuts() map[string]string {
-	shortcuts := make(map[string]string)
-	if h.tui != nil && h.tui.shortcutRegistry != nil {
-		allEntries := h.tui.shortcutRegistry.GetAll()
-		for key, entry := range allEntries {
-			shortcuts[key] = entry.Description
+// keyBindingLines lists the keys of every built-in action as the keymap binds
+// them (DefaultKeymap with the keymap file applied), in keyActions order, after
+// the tab jump keys. Actions are named as in the keymap file.
+func (h *shortcutsInteractiveHandler) keyBindingLines() []string {
+	km := DefaultKeymap()
+	if h.tui != nil {
+		km = h.tui.keymap
+	}
+	const keyWidth = 16
+	lines := []string{"  • " + padRight("alt+1..9 f1..f12", keyWidth) + " - " + tabJumpOwner}
+	for _, action := range keyActions {
+		if keys := km.keys(action); len(keys) > 0 {
+			lines = append(lines, "  • "+padRight(Convert(keys).Join(" ").String(), keyWidth)+" - "+string(action))
+		}
+	}
+	return lines
+}
+
+// registeredShortcutLines lists the registered shortcuts grouped by tab (in tab
+// order) and handler (in registration order), each handler's keys in the order
+// its Shortcuts() returned them. Remote handlers are marked "(remote)", tab and
+// field scoped keys show their scope and conflicting keys are flagged.
+func (h *shortcutsInteractiveHandler) registeredShortcutLines() []string {
+	registry := h.tui.shortcutRegistry
+	entries := registry.Entries()
+
+	type handlerGroup struct {
+		name    string
+		remote  bool
+		entries []*ShortcutEntry
+	}
+	byTab := make(map[int][]*handlerGroup)
+	var tabs []int
+	for _, e := range entries {
+		groups, seenTab := byTab[e.TabIndex]
+		if !seenTab {
+			tabs = append(tabs, e.TabIndex)
+		}
+		var group *handlerGroup
+		for _, g := range groups {
+			if g.name == e.HandlerName && g.remote == e.Remote {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &handlerGroup{name: e.HandlerName, remote: e.Remote}
+			byTab[e.TabIndex] = append(groups, group)
+		}
+		group.entries = append(group.entries, e)
+	}
+	slices.Sort(tabs)
+
+	keyWidth := 0
+	for _, e := range entries {
+		keyWidth = max(keyWidth, len(e.Key))
+	}
+
+	var lines []string
+	for _, tabIndex := range tabs {
+		title := Sprintf("tab %d", tabIndex+1)
+		if tabIndex < len(h.tui.TabSections) {
+			title = h.tui.TabSections[tabIndex].Title
+		}
+		lines = append(lines, "  "+title)
+		for _, g := range byTab[tabIndex] {
+			name := g.name
+			if g.remote {
+				name += " (remote)"
+			}
+			lines = append(lines, "    "+name)
+			for _, e := range g.entries {
+				line := "      • " + padRight(e.Key, keyWidth) + " - " + e.Description
+				if e.Scope != ScopeGlobal {
+					line += " [" + e.Scope.String() + "]"
+				}
+				for _, c := range registry.conflictsFor(e.Key) {
+					line += " ⚠ conflict " + c.String()
+				}
+				lines = append(lines, line)
+			}
 		}
 	}
-	return shortcuts
+	return lines
 }
@@ -1,66 +1,233 @@
 package devtui
 
-import "sync"
+import (
+	"sync"
+
+	. "github.com/tinywasm/fmt"
+)
 
 // ShortcutEntry represents a registered shortcut
 type ShortcutEntry struct {
-	Key         string // The shortcut key (e.g., "c", "d", "p")
-	Description string // Human-readable description (e.g., "coding mode", "debug mode")
-	TabIndex    int    // Index of the tab containing the handler
-	FieldIndex  int    // Index of the field within the tab
-	HandlerName string // Handler name for identification
-	Value       string // Value to pass to Change()
+	Key         string        // The shortcut key spec, normalized (e.g., "c", "ctrl+b", "f5", "g d")
+	Description string        // Human-readable description (e.g., "coding mode", "debug mode")
+	Scope       ShortcutScope // Where the shortcut is active (global, its tab, its field)
+	TabIndex    int           // Index of the tab containing the handler
+	FieldIndex  int           // Index of the field within the tab
+	HandlerName string        // Handler name for identification
+	Value       string        // Value to pass to Change()
+	Remote      bool          // registered from a daemon StateEntry (client mode)
+	Daemon      string        // URL of the daemon that reported a remote shortcut (see remote_daemon.go)
 }
 
-// ShortcutRegistry manages global shortcut keys
+// ShortcutRegistry manages shortcut keys.
+// Keys are key specs (see key_spec.go): single characters, modifier keys
+// ("ctrl+b", "alt+d", "F5") and chords ("g d"). They are stored normalized.
+// The same key may be registered once per scope (see ShortcutScope).
 type ShortcutRegistry struct {
-	mu        sync.RWMutex
-	shortcuts map[string]*ShortcutEntry // key -> entry
+	mu       sync.RWMutex
+	entries  []*ShortcutEntry  // registration order
+	reserved map[string]string // normalized key -> owner (built-in keys handlers cannot register)
+
+	policy    ShortcutConflictPolicy // what Register does when a key is taken (TuiConfig.ShortcutConflictPolicy)
+	logger    func(messages ...any)  // reports resolved conflicts (TuiConfig.Logger)
+	conflicts []ShortcutConflict     // every conflict detected, in order
 }
 
 func newShortcutRegistry() *ShortcutRegistry {
 	return &ShortcutRegistry{
-		shortcuts: make(map[string]*ShortcutEntry),
+		reserved: make(map[string]string),
+	}
+}
+
+// normalizeKey returns the normalized form of a key spec, or the spec itself if it is invalid.
+func normalizeKey(key string) string {
+	strokes, err := parseKeySpec(key)
+	if err != nil {
+		return key
 	}
+	return Convert(strokes).Join(" ").String()
 }
 
+// Register adds a handler shortcut, reporting a rejected key through the
+// registry logger (TuiConfig.Logger). See TryRegister for the rules.
 func (sr *ShortcutRegistry) Register(key string, entry *ShortcutEntry) {
+	if err := sr.TryRegister(key, entry); err != nil && sr.logger != nil {
+		sr.logger(err)
+	}
+}
+
+// TryRegister adds a handler shortcut. It returns an error when the key spec is
+// invalid, reserved by the TUI itself (tab jump keys, navigation keys...) or
+// ambiguous with a registered chord that can be active at the same time
+// (e.g. "g" and "g d", both global or in the same tab).
+// A key already taken in the same scope by another handler is a conflict: it is
+// recorded (see Conflicts) and resolved with the registry ShortcutConflictPolicy.
+func (sr *ShortcutRegistry) TryRegister(key string, entry *ShortcutEntry) error {
+	strokes, err := parseKeySpec(key)
+	if err != nil {
+		return err
+	}
+	normalized := Convert(strokes).Join(" ").String()
+
+	var report string // resolved conflict, logged once sr.mu is released
+	defer func() {
+		if report != "" && sr.logger != nil {
+			sr.logger(report)
+		}
+	}()
 	sr.mu.Lock()
 	defer sr.mu.Unlock()
-	sr.shortcuts[key] = entry
+	// A reserved key can be neither the shortcut nor the start of a chord
+	for _, k := range []string{normalized, strokes[0]} {
+		if owner, reserved := sr.reserved[k]; reserved {
+			return Err(Sprintf("shortcut '%s' of %s is reserved for %s", key, entry.HandlerName, owner))
+		}
+	}
+	for _, e := range sr.entries {
+		if !activeTogether(e, entry) {
+			continue
+		}
+		if isChordPrefix(e.Key, normalized) || isChordPrefix(normalized, e.Key) {
+			return Err(Sprintf("shortcut '%s' of %s is ambiguous with '%s' of %s", key, entry.HandlerName, e.Key, e.HandlerName))
+		}
+	}
+	entry.Key = normalized
+	for i, existing := range sr.entries {
+		if !sameShortcutSlot(existing, entry) {
+			continue
+		}
+		if isConflict(existing, entry) {
+			replace, msg, err := sr.resolveConflict(normalized, existing, entry)
+			if report = msg; !replace {
+				return err
+			}
+		}
+		sr.entries[i] = entry
+		return nil
+	}
+	sr.entries = append(sr.entries, entry)
+	return nil
+}
+
+// isChordPrefix reports whether chord prefix is a stroke prefix of chord ("g" of "g d").
+func isChordPrefix(prefix, chord string) bool {
+	return len(chord) > len(prefix) && chord[:len(prefix)] == prefix && chord[len(prefix)] == ' '
+}
+
+// Reserve marks key as owned by a built-in TUI action so handlers cannot register it.
+func (sr *ShortcutRegistry) Reserve(key, owner string) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.reserved[normalizeKey(key)] = owner
+}
+
+// IsReserved reports whether key is reserved and by whom.
+func (sr *ShortcutRegistry) IsReserved(key string) (string, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	owner, reserved := sr.reserved[normalizeKey(key)]
+	return owner, reserved
 }
 
+// Get returns the entry of key ignoring tab/field scopes: the global entry if
+// there is one, otherwise the first one registered. Use GetInScope for the
+// entry a key press would run.
 func (sr *ShortcutRegistry) Get(key string) (*ShortcutEntry, bool) {
 	sr.mu.RLock()
 	defer sr.mu.RUnlock()
-	entry, exists := sr.shortcuts[key]
-	return entry, exists
+	key = normalizeKey(key)
+	var found *ShortcutEntry
+	for _, e := range sr.entries {
+		if e.Key != key {
+			continue
+		}
+		if e.Scope == ScopeGlobal {
+			return e, true
+		}
+		if found == nil {
+			found = e
+		}
+	}
+	return found, found != nil
 }
 
+// GetInScope returns the entry key runs when tabIndex/fieldIndex is active:
+// field scope wins over tab scope, which wins over global.
+func (sr *ShortcutRegistry) GetInScope(key string, tabIndex, fieldIndex int) (*ShortcutEntry, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	return sr.lookupLocked(normalizeKey(key), tabIndex, fieldIndex)
+}
+
+func (sr *ShortcutRegistry) lookupLocked(key string, tabIndex, fieldIndex int) (*ShortcutEntry, bool) {
+	var best *ShortcutEntry
+	for _, e := range sr.entries {
+		if e.Key == key && e.activeIn(tabIndex, fieldIndex) && (best == nil || e.Scope > best.Scope) {
+			best = e
+		}
+	}
+	return best, best != nil
+}
+
+// Lookup resolves a sequence of pressed strokes in the active tab/field: it
+// returns the entry when the sequence is a complete shortcut, or pending=true
+// when it starts a chord.
+func (sr *ShortcutRegistry) Lookup(strokes []string, tabIndex, fieldIndex int) (entry *ShortcutEntry, pending bool) {
+	seq := Convert(strokes).Join(" ").String()
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	if entry, exists := sr.lookupLocked(seq, tabIndex, fieldIndex); exists {
+		return entry, false
+	}
+	for _, e := range sr.entries {
+		if isChordPrefix(seq, e.Key) && e.activeIn(tabIndex, fieldIndex) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// Unregister removes every entry of key, in all scopes.
 func (sr *ShortcutRegistry) Unregister(key string) {
 	sr.mu.Lock()
 	defer sr.mu.Unlock()
-	delete(sr.shortcuts, key)
+	key = normalizeKey(key)
+	kept := sr.entries[:0]
+	for _, e := range sr.entries {
+		if e.Key != key {
+			kept = append(kept, e)
+		}
+	}
+	sr.entries = kept
 }
 
+// List returns the registered keys (once each, in registration order).
 func (sr *ShortcutRegistry) List() []string {
 	sr.mu.RLock()
 	defer sr.mu.RUnlock()
-	keys := make([]string, 0, len(sr.shortcuts))
-	for k := range sr.shortcuts {
-		keys = append(keys, k)
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(sr.entries))
+	for _, e := range sr.entries {
+		if !seen[e.Key] {
+			seen[e.Key] = true
+			keys = append(keys, e.Key)
+		}
 	}
 	return keys
 }
 
-// GetAll returns all registered shortcuts for UI display
-func (sr *ShortcutRegistry) GetAll() map[string]*ShortcutEntry {
+// Entries returns every registered shortcut, all scopes, in registration order.
+func (sr *ShortcutRegistry) Entries() []*ShortcutEntry {
 	sr.mu.RLock()
 	defer sr.mu.RUnlock()
-	// Return a copy to prevent external modification
+	return append([]*ShortcutEntry(nil), sr.entries...)
+}
+
+// GetAll returns all registered shortcuts for UI display, one per key (see Get)
+func (sr *ShortcutRegistry) GetAll() map[string]*ShortcutEntry {
 	result := make(map[string]*ShortcutEntry)
-	for k, v := range sr.shortcuts {
-		result[k] = v
+	for _, key := range sr.List() {
+		result[key], _ = sr.Get(key)
 	}
 	return result
 }
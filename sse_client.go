@@ -1,7 +1,6 @@
 package devtui
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -10,7 +9,6 @@ import (
 
 	tinyctx "github.com/tinywasm/context"
 	. "github.com/tinywasm/fmt"
-	"github.com/tinywasm/mcp"
 )
 
 // tabContentDTO is a Data Transfer Object for tabContent JSON
@@ -27,6 +25,7 @@ type tabContentDTO struct {
 	OperationID    *string     `json:"operation_id"`
 	IsProgress     bool        `json:"is_progress"`
 	IsComplete     bool        `json:"is_complete"`
+	DurationMs     int64       `json:"duration_ms,omitempty"` // LogOpen to LogClose time of a close line
 }
 
 // actionBaseURL strips the /logs suffix from ClientURL to get the daemon base URL.
@@ -35,8 +34,19 @@ func (h *DevTUI) actionBaseURL() string {
 	return strings.TrimSuffix(h.ClientURL, "/logs")
 }
 
-// startSSEClient connects to the SSE endpoint and processes incoming logs
+// startSSEClient connects the primary daemon (see remote_daemon.go) to the SSE endpoint.
 func (h *DevTUI) startSSEClient(url string, ctx context.Context) {
+	d := h.primaryDaemon()
+	if d == nil {
+		h.sseWg.Done()
+		return
+	}
+	d.startSSEClient(url, ctx)
+}
+
+// startSSEClient connects to the daemon's SSE endpoint and processes incoming logs
+func (d *remoteDaemon) startSSEClient(url string, ctx context.Context) {
+	h := d.tui
 	defer h.sseWg.Done()
 
 	// Ensure URL has protocol
@@ -45,14 +55,12 @@ func (h *DevTUI) startSSEClient(url string, ctx context.Context) {
 	}
 
 	// Fetch initial state snapshot before entering the retry loop
-	h.fetchAndReconstructState()
+	d.fetchAndReconstructState()
 
 	client := &http.Client{
 		Timeout: 0, // Infinite timeout for SSE
 	}
 
-	retryDelay := 1 * time.Second
-
 	for {
 		// Check for cancellation before each connection attempt
 		select {
@@ -65,39 +73,67 @@ func (h *DevTUI) startSSEClient(url string, ctx context.Context) {
 			h.Logger("Connecting to SSE stream at", url)
 		}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		attemptCtx, cancelAttempt := d.conn.attemptContext(ctx)
+		req, err := http.NewRequestWithContext(attemptCtx, "GET", url, nil)
 		if err != nil {
+			cancelAttempt()
 			if ctx.Err() != nil {
 				return // context cancelled
 			}
 			if !h.isShuttingDown.Load() && h.Logger != nil {
 				h.Logger("Error creating SSE request:", err)
 			}
-			time.Sleep(retryDelay)
+			if !d.conn.waitRetry(ctx, ConnReconnecting) {
+				return
+			}
 			continue
 		}
 
 		req.Header.Set("Accept", "text/event-stream")
 		req.Header.Set("Cache-Control", "no-cache")
 		req.Header.Set("Connection", "keep-alive")
-		if h.apiKey != "" {
-			req.Header.Set("Authorization", "Bearer "+h.apiKey)
+		if id := d.conn.resumeID(); id != "" {
+			req.Header.Set("Last-Event-ID", id) // daemon resumes after the last event we got
+		}
+		if d.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+d.apiKey)
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
+			cancelAttempt()
 			if ctx.Err() != nil {
 				return // context cancelled — clean exit, no log
 			}
 			if !h.isShuttingDown.Load() && h.Logger != nil {
 				h.Logger("Error connecting to SSE server:", err)
 			}
-			time.Sleep(retryDelay)
+			if !d.conn.waitRetry(ctx, ConnReconnecting) {
+				return
+			}
 			continue
 		}
 
-		reader := bufio.NewReader(resp.Body)
-		var currentEvent string
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			cancelAttempt()
+			retryState := ConnReconnecting
+			if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+				retryState = ConnAuthFailed
+			}
+			if !h.isShuttingDown.Load() && h.Logger != nil {
+				h.Logger("SSE server answered", resp.Status)
+			}
+			if !d.conn.waitRetry(ctx, retryState) {
+				return
+			}
+			continue
+		}
+		if d.conn.setState(ConnLive) {
+			d.fetchAndReconstructState() // state patches sent while disconnected were missed
+		}
+
+		parser := newSSEParser(resp.Body, d.conn.resumeID(), d.conn.setRetry)
 
 		// Process the stream
 		for {
@@ -109,52 +145,61 @@ func (h *DevTUI) startSSEClient(url string, ctx context.Context) {
 			default:
 			}
 
-			line, err := reader.ReadString('\n')
+			event, err := parser.next()
 			if err != nil {
 				resp.Body.Close()
 				if ctx.Err() != nil {
 					return // context cancelled mid-stream
 				}
+				if attemptCtx.Err() != nil {
+					break // "reconnect now": connect again without waiting
+				}
 				if !h.isShuttingDown.Load() && h.Logger != nil {
 					h.Logger("Error reading SSE stream:", err)
 				}
 				break // Break inner loop to reconnect
 			}
 
-			line = strings.TrimSpace(line)
-
-			if strings.HasPrefix(line, "event:") {
-				currentEvent = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			if event.ID != "" {
+				d.conn.setLastEventID(event.ID)
+			}
+			if event.Data == "" {
 				continue
 			}
-
-			if strings.HasPrefix(line, "data:") {
-				data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
-				if data == "" {
-					continue
-				}
-				switch currentEvent {
-				default: // "" or "log"
-					h.handleLogEvent(data)
-				}
-				currentEvent = "" // reset after data line
+			switch event.Event {
+			case "tabs":
+				d.handleTabsEvent(event.Data)
+			case "state":
+				d.handleStateEvent(event.Data)
+			case "state-patch":
+				d.handleStatePatchEvent(event.Data)
+			default: // "message" or "log"
+				d.handleLogEvent(event.Data)
 			}
 		}
 
 		// resp.Body already closed above in all paths
-		time.Sleep(retryDelay)
+		if attemptCtx.Err() != nil {
+			d.conn.setState(ConnConnecting)
+			continue
+		}
+		cancelAttempt()
+		if !d.conn.waitRetry(ctx, ConnReconnecting) {
+			return
+		}
 	}
 }
 
-// mcpClient builds a stateless JSON-RPC client targeting the daemon's /mcp endpoint.
-// ClientURL = "http://host:port/logs" → base URL = "http://host:port"
-func (h *DevTUI) mcpClient() *mcp.Client {
-	baseURL := strings.TrimSuffix(h.ClientURL, "/logs")
-	return mcp.NewClient(baseURL, h.apiKey)
+// handleLogEvent processes a plain log SSE data line of the primary daemon.
+func (h *DevTUI) handleLogEvent(data string) {
+	if d := h.primaryDaemon(); d != nil {
+		d.handleLogEvent(data)
+	}
 }
 
 // handleLogEvent processes a plain log SSE data line.
-func (h *DevTUI) handleLogEvent(data string) {
+func (d *remoteDaemon) handleLogEvent(data string) {
+	h := d.tui
 	var dto tabContentDTO
 	if err := json.Unmarshal([]byte(data), &dto); err != nil {
 		if !h.isShuttingDown.Load() && h.Logger != nil {
@@ -165,17 +210,17 @@ func (h *DevTUI) handleLogEvent(data string) {
 
 	// HandlerType 0 = TypeStateRefresh signal from daemon
 	if dto.HandlerType == 0 {
-		h.fetchAndReconstructState()
+		d.fetchAndReconstructState()
 		return
 	}
 
-	var section *tabSection
-	for _, s := range h.TabSections {
-		if s.Title == dto.TabTitle {
-			section = s
-			break
-		}
+	// A daemon replaying its buffer after a reconnect resends lines already shown.
+	// Tracked lines keep their Id across updates, so the timestamp is part of the key.
+	if dto.Id != "" && d.conn.markSeen(dto.Id+"|"+dto.Timestamp) {
+		return
 	}
+
+	section := d.logTab(dto.TabTitle)
 	if section == nil {
 		return
 	}
@@ -193,35 +238,18 @@ func (h *DevTUI) handleLogEvent(data string) {
 		RawHandlerName: dto.HandlerName,
 		handlerColor:   dto.HandlerColor,
 		handlerType:    dto.HandlerType,
+		duration:       time.Duration(dto.DurationMs) * time.Millisecond,
 	}
 
-	section.mu.Lock()
-	section.tabContents = append(section.tabContents, content)
-	if len(section.tabContents) > 500 {
-		section.tabContents = section.tabContents[len(section.tabContents)-500:]
-	}
-	section.mu.Unlock()
+	content = section.applyRemoteContent(content)
+	section.animateRemoteContent(content)
 
 	h.tabContentsChan <- content
 }
 
-// clearRemoteHandlers removes all fields that were added via SSE state reconstruction.
-// Called before applying a new state event so stale remote handlers don't accumulate.
-func (h *DevTUI) clearRemoteHandlers() {
-	for _, s := range h.TabSections {
-		filtered := s.FieldHandlers[:0]
-		for _, f := range s.FieldHandlers {
-			if !f.isRemote {
-				filtered = append(filtered, f)
-			}
-		}
-		s.FieldHandlers = filtered
-	}
-}
-
 // fetchAndReconstructState fetches the daemon state snapshot and builds remote handlers via JSON-RPC.
-func (h *DevTUI) fetchAndReconstructState() {
-	h.mcpClient().Call(tinyctx.Background(), "tinywasm/state", nil, func(result []byte, err error) {
+func (d *remoteDaemon) fetchAndReconstructState() {
+	d.client.Call(tinyctx.Background(), "tinywasm/state", nil, func(result []byte, err error) {
 		if err != nil || result == nil {
 			return
 		}
@@ -230,31 +258,9 @@ func (h *DevTUI) fetchAndReconstructState() {
 			return
 		}
 		if len(entries) == 0 {
+			d.applyEmptyState()
 			return
 		}
-		h.clearRemoteHandlers()
-		h.reconstructRemoteHandlers(entries)
-		h.RefreshUI()
+		d.applyState(entries)
 	})
 }
-
-// reconstructRemoteHandlers populates sections with remote fields from state entries.
-func (h *DevTUI) reconstructRemoteHandlers(entries []StateEntry) {
-	client := h.mcpClient()
-	for _, entry := range entries {
-		var section *tabSection
-		for _, s := range h.TabSections {
-			if s.Title == entry.TabTitle {
-				section = s
-				break
-			}
-		}
-		if section == nil {
-			continue
-		}
-		f := newRemoteField(entry, client, section, h)
-		if f != nil {
-			section.addFields(f)
-		}
-	}
-}
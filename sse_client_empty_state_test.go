@@ -10,39 +10,27 @@ import (
 //
 // Symptom: user's input section disappears after MCP start_development call.
 // The devtui calls fetchAndReconstructState on each StateRefresh SSE event.
-// If state is empty (daemon race: projectTui == nil), clearRemoteHandlers() wipes inputs.
+// If state is empty (daemon race: projectTui == nil), reconciling it removes every input.
 //
-// Fix: in fetchAndReconstructState, skip the update when entries is empty:
+// Fix: in fetchAndReconstructState (and for an "event: state"), skip the update
+// when entries is empty:
 //
 //	if len(entries) == 0 { return }
 func TestFetchAndReconstructState_EmptyResponseDoesNotClearHandlers(t *testing.T) {
 	tui := newTestTUI()
 	section := tui.TabSections[0]
+	d := tui.primaryDaemon()
 
-	// Pre-populate with a remote field (simulating a previously reconstructed state).
-	remoteField := &field{
-		handler:   &anyHandler{nameFunc: func() string { return "CompilerMode" }},
-		parentTab: section,
-		isRemote:  true,
-	}
-	section.addFields(remoteField)
-
+	// Pre-populate with a remote field (a previously applied state).
+	d.applyState([]StateEntry{{TabTitle: "BUILD", HandlerName: "CompilerMode", HandlerType: HandlerTypeEdit}})
 	if len(section.FieldHandlers) == 0 {
 		t.Fatal("precondition: remote field should have been added")
 	}
 
-	// Simulate the FIXED path inside fetchAndReconstructState when entries == []:
-	// With the fix: if len(entries)==0, return early (skip clearRemoteHandlers).
-	fixedHandleEmptyState := func(entries []StateEntry) {
-		if len(entries) == 0 {
-			return  // FIX: skip update on empty state
-		}
-		tui.clearRemoteHandlers()
-		// reconstructRemoteHandlers(entries) would add new handlers here
-	}
-	fixedHandleEmptyState([]StateEntry{}) // empty entries — simulates nil projectTui response
+	// Empty snapshots, fetched or pushed, are skipped.
+	d.applyEmptyState()
+	d.handleStateEvent(`[]`)
 
-	// With the fix: remote fields should still be present (update was skipped).
 	hasRemote := false
 	for _, f := range section.FieldHandlers {
 		if f.isRemote {
@@ -59,42 +47,40 @@ func TestFetchAndReconstructState_EmptyResponseDoesNotClearHandlers(t *testing.T
 	}
 }
 
-// TestClearRemoteHandlers_PreservesNonRemoteFields verifies that clearRemoteHandlers
+// TestReconcileRemoteHandlers_PreservesNonRemoteFields verifies that a snapshot
 // only removes fields tagged as remote (isRemote=true), leaving local fields untouched.
-func TestClearRemoteHandlers_PreservesNonRemoteFields(t *testing.T) {
+func TestReconcileRemoteHandlers_PreservesNonRemoteFields(t *testing.T) {
 	tui := newTestTUI()
 	section := tui.TabSections[0]
+	d := tui.primaryDaemon()
 
 	localField := &field{
 		handler:   &anyHandler{nameFunc: func() string { return "LocalField" }},
 		parentTab: section,
 		isRemote:  false,
 	}
-	remoteField := &field{
-		handler:   &anyHandler{nameFunc: func() string { return "RemoteField" }},
-		parentTab: section,
-		isRemote:  true,
-	}
-	section.addFields(localField, remoteField)
+	section.addFields(localField)
+	d.applyState([]StateEntry{{TabTitle: "BUILD", HandlerName: "RemoteField", HandlerType: HandlerTypeEdit}})
 
-	tui.clearRemoteHandlers()
+	d.reconcileRemoteHandlers([]StateEntry{{TabTitle: "OTHER", HandlerName: "Elsewhere", HandlerType: HandlerTypeEdit}})
 
 	if len(section.FieldHandlers) != 1 {
-		t.Errorf("Expected 1 field after clearRemoteHandlers (local only), got %d", len(section.FieldHandlers))
+		t.Errorf("Expected 1 field after reconciling (local only), got %d", len(section.FieldHandlers))
 	}
 	if section.FieldHandlers[0].isRemote {
 		t.Errorf("Remaining field should be the local (non-remote) field")
 	}
 }
 
-// newTestTUI creates a minimal DevTUI with one tab section for testing.
+// newTestTUI creates a minimal DevTUI with one tab section and a daemon for testing.
 func newTestTUI() *DevTUI {
-	tui := &DevTUI{}
+	tui := &DevTUI{TuiConfig: &TuiConfig{}}
 	section := &tabSection{
 		Title: "BUILD",
 		tui:   tui,
 		Index: 0,
 	}
 	tui.TabSections = []*tabSection{section}
+	tui.daemons = []*remoteDaemon{newRemoteDaemon(tui, Endpoint{})}
 	return tui
 }
@@ -0,0 +1,253 @@
+package devtui
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// ConnectionState is the state of the client mode SSE connection, shown as a
+// badge in the header (see connectionBadge).
+type ConnectionState int
+
+const (
+	ConnConnecting   ConnectionState = iota // first connection attempt in progress
+	ConnLive                                // stream open
+	ConnReconnecting                        // waiting for the next attempt (sseConn.retryAt)
+	ConnAuthFailed                          // daemon answered 401/403: APIKey rejected
+)
+
+var connectionStateNames = [...]string{"connecting", "live", "reconnecting", "auth failed"}
+
+func (s ConnectionState) String() string {
+	if s < 0 || int(s) >= len(connectionStateNames) {
+		return "unknown"
+	}
+	return connectionStateNames[s]
+}
+
+// Reconnection backoff: sseBaseDelay (or the stream's "retry:" field) doubled per failed attempt up to sseMaxDelay,
+// with jitter so many clients restarting with a daemon don't reconnect in lockstep.
+const (
+	sseBaseDelay = 1 * time.Second
+	sseMaxDelay  = 30 * time.Second
+)
+
+// sseConn tracks the SSE connection state and its reconnection backoff.
+type sseConn struct {
+	mu        sync.Mutex
+	state     ConnectionState
+	attempt   int       // failed attempts since the last successful connection
+	connected bool      // the stream was live at least once
+	retryAt   time.Time // next attempt (ConnReconnecting / ConnAuthFailed)
+	cancel    context.CancelFunc
+	wake      chan struct{} // "reconnect now": skips the backoff wait
+
+	baseDelay   time.Duration   // first retry delay, sseBaseDelay unless the daemon sent "retry:"
+	lastEventID string          // sent as Last-Event-ID on reconnect
+	seen        map[string]bool // log events already applied (see markSeen)
+	seenOrder   []string        // seen keys, oldest first, bounded by maxSeenEvents
+}
+
+// maxSeenEvents bounds the log events remembered for deduplication; a daemon
+// replays at most its buffer, which is smaller.
+const maxSeenEvents = 2000
+
+func newSSEConn() *sseConn {
+	return &sseConn{wake: make(chan struct{}, 1), baseDelay: sseBaseDelay, seen: make(map[string]bool)}
+}
+
+// setRetry applies the "retry:" field of the stream as the base reconnection
+// delay, clamped to (0, sseMaxDelay]: the value comes from the daemon.
+func (c *sseConn) setRetry(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseDelay = min(d, sseMaxDelay)
+}
+
+// setLastEventID records the ID of the last event received.
+func (c *sseConn) setLastEventID(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastEventID = id
+}
+
+// resumeID returns the Last-Event-ID to send on (re)connect ("" = none yet).
+func (c *sseConn) resumeID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastEventID
+}
+
+// markSeen records a log event key and reports whether it was already applied
+// (a daemon replaying its buffer after a reconnect).
+func (c *sseConn) markSeen(key string) (duplicate bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[key] {
+		return true
+	}
+	c.seen[key] = true
+	c.seenOrder = append(c.seenOrder, key)
+	if len(c.seenOrder) > maxSeenEvents {
+		delete(c.seen, c.seenOrder[0])
+		c.seenOrder = c.seenOrder[1:]
+	}
+	return false
+}
+
+// setState records the connection state; ConnLive resets the backoff.
+// It reports whether a ConnLive is a reconnection (the stream was live before).
+func (c *sseConn) setState(state ConnectionState) (reconnected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = state
+	if state == ConnLive {
+		c.attempt = 0
+		reconnected = c.connected
+		c.connected = true
+	}
+	return reconnected
+}
+
+// snapshot returns the state and the time of the next attempt.
+func (c *sseConn) snapshot() (ConnectionState, time.Time) {
+	if c == nil {
+		return ConnConnecting, time.Time{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state, c.retryAt
+}
+
+// attemptContext derives the context of one connection attempt: "reconnect now"
+// cancels it to drop a live stream. A pending "reconnect now" is consumed, so
+// it cannot skip the backoff of a later failure.
+func (c *sseConn) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	select {
+	case <-c.wake:
+	default:
+	}
+	attemptCtx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+	return attemptCtx, cancel
+}
+
+// nextDelay returns the wait before the next attempt: baseDelay * 2^attempt,
+// capped at sseMaxDelay, with jitter in [delay/2, delay].
+func (c *sseConn) nextDelay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delay := max(c.baseDelay, time.Millisecond)
+	for i := 0; i < c.attempt && delay < sseMaxDelay; i++ {
+		delay *= 2 // stops doubling at the cap, so it cannot overflow
+	}
+	delay = min(delay, sseMaxDelay)
+	c.attempt++
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// waitRetry sleeps before the next attempt, keeping the state (auth failed or
+// reconnecting) and the countdown for the header. It returns false when ctx is done.
+func (c *sseConn) waitRetry(ctx context.Context, state ConnectionState) bool {
+	delay := c.nextDelay()
+	c.mu.Lock()
+	c.state = state
+	c.retryAt = time.Now().Add(delay)
+	c.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-c.wake:
+	case <-timer.C:
+	}
+	c.setState(ConnConnecting)
+	return true
+}
+
+// reconnectNow skips the backoff wait, or drops the live stream to reconnect.
+func (c *sseConn) reconnectNow() {
+	c.mu.Lock()
+	c.attempt = 0
+	if c.state == ConnLive && c.cancel != nil {
+		c.cancel()
+	}
+	c.mu.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// reconnectSSE is the "reconnect" keymap action (Ctrl+R) in client mode.
+func (h *DevTUI) reconnectSSE() {
+	if !h.ClientMode {
+		return
+	}
+	if h.Logger != nil {
+		h.Logger("Reconnecting to SSE stream now")
+	}
+	for _, d := range h.daemons {
+		d.conn.reconnectNow()
+	}
+}
+
+// ConnectionStatus returns the client mode SSE connection state of the first
+// endpoint (see ConnectionStatuses for every endpoint).
+func (h *DevTUI) ConnectionStatus() ConnectionState {
+	var conn *sseConn
+	if d := h.primaryDaemon(); d != nil {
+		conn = d.conn
+	}
+	state, _ := conn.snapshot()
+	return state
+}
+
+// connectionBadge renders the SSE connection state of every endpoint for the
+// header ("" outside client mode).
+func (h *DevTUI) connectionBadge() string {
+	return Convert(h.connectionBadges()).Join(" ").String()
+}
+
+// connectionBadges renders the connection badge of each endpoint (none outside client mode).
+func (h *DevTUI) connectionBadges() []string {
+	if !h.ClientMode {
+		return nil
+	}
+	badges := make([]string, 0, len(h.daemons))
+	for _, d := range h.daemons {
+		badges = append(badges, h.daemonBadge(d))
+	}
+	return badges
+}
+
+// daemonBadge renders the connection state of one endpoint, prefixed by its name.
+func (h *DevTUI) daemonBadge(d *remoteDaemon) string {
+	prefix := ""
+	if d.name != "" {
+		prefix = d.name + " "
+	}
+	state, retryAt := d.conn.snapshot()
+	switch state {
+	case ConnLive:
+		return h.successStyle.Render(prefix + "● live")
+	case ConnReconnecting:
+		secs := int((time.Until(retryAt) + time.Second - 1) / time.Second)
+		return h.warnStyle.Render(Sprintf("%s↻ reconnecting in %ds", prefix, max(secs, 0)))
+	case ConnAuthFailed:
+		return h.errStyle.Render(prefix + "✖ auth failed")
+	default:
+		return h.infoStyle.Render(prefix + "◌ connecting")
+	}
+}
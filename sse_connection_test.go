@@ -0,0 +1,126 @@
+package devtui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSSEConn_BackoffDoublesWithJitterAndCap(t *testing.T) {
+	c := newSSEConn()
+	for attempt, max := range []time.Duration{1, 2, 4, 8, 16, 30, 30} {
+		max *= time.Second
+		d := c.nextDelay()
+		if d < max/2 || d > max {
+			t.Errorf("attempt %d: delay %s outside [%s, %s]", attempt, d, max/2, max)
+		}
+	}
+	c.setState(ConnLive)
+	if d := c.nextDelay(); d > sseBaseDelay {
+		t.Errorf("a live connection should reset the backoff, got %s", d)
+	}
+}
+
+func TestSSEConn_ReconnectNowDoesNotLinger(t *testing.T) {
+	c := newSSEConn()
+	c.setState(ConnLive)
+	c.reconnectNow()
+	_, cancel := c.attemptContext(context.Background())
+	defer cancel()
+
+	ctx, stop := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer stop()
+	c.setRetry(time.Second)
+	start := time.Now()
+	c.waitRetry(ctx, ConnReconnecting)
+	if time.Since(start) < 40*time.Millisecond {
+		t.Error("a reconnect request consumed by an attempt should not skip a later backoff")
+	}
+}
+
+func TestSSEConn_RetryIsClamped(t *testing.T) {
+	c := newSSEConn()
+	c.setRetry(-time.Second)
+	if c.baseDelay != sseBaseDelay {
+		t.Errorf("a non-positive retry should be ignored, got %s", c.baseDelay)
+	}
+	c.setRetry(1 << 62)
+	for attempt := range 70 {
+		if d := c.nextDelay(); d <= 0 || d > sseMaxDelay {
+			t.Fatalf("attempt %d: delay %s outside (0, %s]", attempt, d, sseMaxDelay)
+		}
+	}
+}
+
+func TestSSEClient_AuthFailedBadgeAndReconnectNow(t *testing.T) {
+	var attempts atomic.Int32
+	sseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs" {
+			return
+		}
+		attempts.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer sseServer.Close()
+
+	config := &TuiConfig{ClientMode: true, ClientURL: sseServer.URL + "/logs", APIKey: "wrong"}
+	tui := NewTUI(config)
+	tui.NewTabSection("LOGS", "")
+	tui.viewport.Width = 120
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tui.sseWg.Add(1)
+	go tui.startSSEClient(config.ClientURL, ctx)
+
+	deadline := time.After(2 * time.Second)
+	for tui.ConnectionStatus() != ConnAuthFailed {
+		select {
+		case <-deadline:
+			t.Fatalf("expected auth failed state, got %s", tui.ConnectionStatus())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if !strings.Contains(tui.headerView(), "auth failed") {
+		t.Error("header should show the auth failed badge")
+	}
+
+	// Ctrl+R skips the backoff wait
+	before := attempts.Load()
+	tui.handleKeyboard(tea.KeyMsg{Type: tea.KeyCtrlR})
+	deadline = time.After(400 * time.Millisecond)
+	for attempts.Load() == before {
+		select {
+		case <-deadline:
+			t.Fatal("Ctrl+R should reconnect without waiting for the backoff")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestConnectionBadge_States(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+
+	conn := tui.primaryDaemon().conn
+	conn.setState(ConnLive)
+	if !strings.Contains(tui.connectionBadge(), "live") {
+		t.Errorf("expected live badge, got %q", tui.connectionBadge())
+	}
+	conn.mu.Lock()
+	conn.state, conn.retryAt = ConnReconnecting, time.Now().Add(4*time.Second)
+	conn.mu.Unlock()
+	if !strings.Contains(tui.connectionBadge(), "reconnecting in 4s") {
+		t.Errorf("expected reconnect countdown, got %q", tui.connectionBadge())
+	}
+
+	local := NewTUI(&TuiConfig{})
+	if local.connectionBadge() != "" {
+		t.Error("no connection badge outside client mode")
+	}
+}
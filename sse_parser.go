@@ -0,0 +1,114 @@
+package devtui
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// sseEvent is one event of a text/event-stream, dispatched on a blank line.
+type sseEvent struct {
+	ID    string // last event ID seen so far on the stream (the "id:" field persists)
+	Event string // "event:" field, "message" when absent
+	Data  string // "data:" lines joined with "\n"
+}
+
+// sseParser reads events following the SSE spec
+// (https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation):
+// multi-line data, "id:" (ignored when it contains NUL), "retry:" (digits only),
+// ":" comments, an optional space after the colon and CRLF/CR/LF line endings.
+type sseParser struct {
+	reader      *bufio.Reader
+	lastEventID string
+	retry       func(time.Duration) // called for each valid "retry:" field (nil = ignored)
+}
+
+func newSSEParser(r io.Reader, lastEventID string, retry func(time.Duration)) *sseParser {
+	return &sseParser{reader: bufio.NewReader(r), lastEventID: lastEventID, retry: retry}
+}
+
+// next returns the next event; the error is the reader's (io.EOF when the stream ends).
+func (p *sseParser) next() (sseEvent, error) {
+	var data strings.Builder
+	hasData := false
+	event := ""
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return sseEvent{}, err
+		}
+
+		if line == "" {
+			if !hasData {
+				event = "" // nothing to dispatch: reset the event type
+				continue
+			}
+			if event == "" {
+				event = "message"
+			}
+			return sseEvent{ID: p.lastEventID, Event: event, Data: strings.TrimSuffix(data.String(), "\n")}, nil
+		}
+		if line[0] == ':' {
+			continue // comment / keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "event":
+			event = value
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				p.lastEventID = value
+			}
+		case "retry":
+			if isDigits(value) && p.retry != nil {
+				if ms, err := Convert(value).Int64(); err == nil {
+					ms = min(ms, int64(sseMaxDelay/time.Millisecond)) // no overflow converting to a Duration
+					p.retry(time.Duration(ms) * time.Millisecond)
+				}
+			}
+		}
+	}
+}
+
+// readLine returns a line without its CRLF, LF or CR terminator.
+func (p *sseParser) readLine() (string, error) {
+	var line strings.Builder
+	for {
+		b, err := p.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '\n':
+			return line.String(), nil
+		case '\r':
+			if next, err := p.reader.Peek(1); err == nil && next[0] == '\n' {
+				p.reader.ReadByte()
+			}
+			return line.String(), nil
+		default:
+			line.WriteByte(b)
+		}
+	}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
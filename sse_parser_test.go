@@ -0,0 +1,106 @@
+package devtui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSSEParser_Spec(t *testing.T) {
+	var retry time.Duration
+	stream := ": keep-alive\r\n" +
+		"id: 1\r\n" +
+		"event: log\r\n" +
+		"data: first line\r\n" +
+		"data:second line\r\n" +
+		"\r\n" +
+		"retry: 2500\n" +
+		"retry: 1x\n" +
+		"id: bad\x00id\n" +
+		"data: {\"a\":1}\n" +
+		"\n" +
+		"id: 3\n" +
+		"\n" + // id only: nothing dispatched but the id is kept
+		"data\n" +
+		"\n" +
+		"data: unterminated"
+	p := newSSEParser(strings.NewReader(stream), "", func(d time.Duration) { retry = d })
+
+	ev, err := p.next()
+	if err != nil || ev.ID != "1" || ev.Event != "log" || ev.Data != "first line\nsecond line" {
+		t.Fatalf("multi-line event: got %+v, %v", ev, err)
+	}
+	ev, err = p.next()
+	if err != nil || ev.ID != "1" || ev.Event != "message" || ev.Data != `{"a":1}` {
+		t.Fatalf("default event type and NUL id: got %+v, %v", ev, err)
+	}
+	if retry != 2500*time.Millisecond {
+		t.Errorf("retry: got %s", retry)
+	}
+	ev, err = p.next()
+	if err != nil || ev.ID != "3" || ev.Data != "" {
+		t.Fatalf("field without colon: got %+v, %v", ev, err)
+	}
+	if _, err = p.next(); err != io.EOF {
+		t.Errorf("an unterminated event must not be dispatched, got %v", err)
+	}
+}
+
+func TestSSEClient_ResumesWithLastEventIDAndDedupes(t *testing.T) {
+	line := func(id, ts, content string) string {
+		data, _ := json.Marshal(tabContentDTO{Id: id, Timestamp: ts, Content: content, TabTitle: "LOGS", HandlerType: handlerTypeLoggable})
+		return "data: " + string(data) + "\n\n"
+	}
+	var connections atomic.Int32
+	resumeID := make(chan string, 1)
+	sseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs" {
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		if connections.Add(1) == 1 {
+			io.WriteString(w, "retry: 10\n"+"id: 7\n"+line("a", "1", "one")+"id: 8\n"+line("b", "2", "two"))
+			return // drop the stream
+		}
+		resumeID <- r.Header.Get("Last-Event-ID")
+		// replay of the buffer plus one new line
+		io.WriteString(w, line("a", "1", "one")+line("b", "2", "two")+"id: 9\n"+line("c", "3", "three"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer sseServer.Close()
+
+	config := &TuiConfig{ClientMode: true, ClientURL: sseServer.URL + "/logs"}
+	tui := NewTUI(config)
+	section := tui.NewTabSection("LOGS", "").(*tabSection)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tui.sseWg.Add(1)
+	go tui.startSSEClient(config.ClientURL, ctx)
+
+	select {
+	case id := <-resumeID:
+		if id != "8" {
+			t.Errorf("expected Last-Event-ID 8 on reconnect, got %q", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the reconnect (retry: 10 should shorten the backoff)")
+	}
+
+	// Lines are applied in stream order: once "three" is shown, the replayed
+	// lines sent before it were handled too
+	waitFor(t, "the line after the replay", func() bool {
+		contents := section.contentsSnapshot()
+		return len(contents) > 0 && contents[len(contents)-1].Content == "three"
+	})
+	if got := len(section.contentsSnapshot()); got != 3 {
+		t.Errorf("replayed lines should be deduplicated, got %d lines", got)
+	}
+}
@@ -1,7 +1,7 @@
 package devtui
 
 // StateEntry is the JSON wire format for a single handler registered in the daemon TUI.
-// Produced by app.HeadlessTUI, consumed by DevTUI client mode.
+// Produced by app.HeadlessTUI and DevTUI headless mode, consumed by DevTUI client mode.
 // JSON tags are the published contract — any producer must match them exactly.
 type StateEntry struct {
 	TabTitle     string              `json:"tab_title"`
@@ -23,3 +23,27 @@ const (
 	HandlerTypeInteractive = 3
 	HandlerTypeLoggable    = 4
 )
+
+// TabDescriptor describes a daemon tab, sent as the JSON array of an
+// "event: tabs" SSE message. Client mode creates, describes and orders its
+// remote tabs from it and removes the auto-created tabs missing from the list.
+type TabDescriptor struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Order       int    `json:"order"` // ascending; ties keep the daemon's list order
+}
+
+// StatePatch is the payload of an "event: state-patch" SSE message: the
+// handlers added or changed since the last snapshot (whole entries, matched by
+// TabTitle and HandlerName) and the handlers gone. "event: state" carries a
+// full []StateEntry snapshot instead.
+type StatePatch struct {
+	Upsert []StateEntry `json:"upsert"`
+	Remove []StateRef   `json:"remove"`
+}
+
+// StateRef identifies a handler in a StatePatch.
+type StateRef struct {
+	TabTitle    string `json:"tab_title"`
+	HandlerName string `json:"handler_name"`
+}
@@ -0,0 +1,115 @@
+package devtui
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Daemon state pushed over SSE: "event: state" carries a full []StateEntry
+// snapshot and "event: state-patch" a StatePatch, so label changes don't cost
+// a tinywasm/state round trip each. The JSON-RPC snapshot fetch is still used
+// for the initial sync, after a reconnect (patches may have been missed) and
+// on the legacy HandlerType 0 refresh signal.
+
+// applyState makes state the current daemon snapshot: the patches received
+// before the first snapshot are merged into it, its remote fields are
+// reconciled and remote tabs without handlers removed, on the UI goroutine.
+func (d *remoteDaemon) applyState(state []StateEntry) {
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+	for _, patch := range d.pending {
+		state = mergePatch(state, patch)
+	}
+	d.pending = nil
+	d.synced = true
+	d.setState(state)
+}
+
+// setState stores and shows state. stateMu is held until the UI goroutine
+// applied it, so snapshots and patches are shown in the order they were merged.
+// Must be called with d.stateMu held.
+func (d *remoteDaemon) setState(state []StateEntry) {
+	d.state = state
+	d.tui.runOnUI(func() {
+		d.reconcileRemoteHandlers(state)
+		d.syncTabs(d.tabTitles(state), tabFromState)
+	})
+}
+
+// applyEmptyState takes an empty snapshot as the first one, without wiping
+// the current fields: only the patches queued for it are applied.
+func (d *remoteDaemon) applyEmptyState() {
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+	if d.synced {
+		return
+	}
+	d.synced = true
+	if len(d.pending) == 0 {
+		return
+	}
+	var state []StateEntry
+	for _, patch := range d.pending {
+		state = mergePatch(state, patch)
+	}
+	d.pending = nil
+	d.setState(state)
+}
+
+// handleStateEvent applies an "event: state" snapshot.
+func (d *remoteDaemon) handleStateEvent(data string) {
+	h := d.tui
+	var state []StateEntry
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		if !h.isShuttingDown.Load() && h.Logger != nil {
+			h.Logger("Error unmarshalling SSE state:", err)
+		}
+		return
+	}
+	if len(state) == 0 {
+		return // same guard as fetchAndReconstructState: never wipe the fields on an empty snapshot
+	}
+	d.applyState(state)
+}
+
+// handleStatePatchEvent merges an "event: state-patch" into the current
+// snapshot. Patches arriving before the first snapshot are queued for it.
+func (d *remoteDaemon) handleStatePatchEvent(data string) {
+	h := d.tui
+	var patch StatePatch
+	if err := json.Unmarshal([]byte(data), &patch); err != nil {
+		if !h.isShuttingDown.Load() && h.Logger != nil {
+			h.Logger("Error unmarshalling SSE state patch:", err)
+		}
+		return
+	}
+
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+	if !d.synced {
+		d.pending = append(d.pending, patch)
+		return
+	}
+	d.setState(mergePatch(slices.Clone(d.state), patch))
+}
+
+// mergePatch applies patch to state: removed entries are dropped, upserted
+// ones replace the entry with the same tab and handler name or are appended.
+func mergePatch(state []StateEntry, patch StatePatch) []StateEntry {
+	state = slices.DeleteFunc(state, func(e StateEntry) bool {
+		return slices.ContainsFunc(patch.Remove, func(r StateRef) bool {
+			return r.TabTitle == e.TabTitle && r.HandlerName == e.HandlerName
+		})
+	})
+	for _, entry := range patch.Upsert {
+		i := slices.IndexFunc(state, func(e StateEntry) bool {
+			return e.TabTitle == entry.TabTitle && e.HandlerName == entry.HandlerName
+		})
+		if i >= 0 {
+			state[i] = entry
+		} else {
+			state = append(state, entry)
+		}
+	}
+	return state
+}
@@ -0,0 +1,119 @@
+package devtui
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStateEvents_SnapshotAndPatch(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+
+	tui.primaryDaemon().handleStateEvent(`[
+		{"tab_title":"BUILD","handler_name":"Mode","handler_type":1,"label":"Mode","value":"dev"},
+		{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"label":"Port","value":"8080"},
+		{"tab_title":"DEPLOY","handler_name":"Deploy","handler_type":2,"label":"Deploy"}]`)
+	build := tui.tabByTitle("BUILD")
+	if build == nil || len(build.FieldHandlers) != 2 || tui.tabByTitle("DEPLOY") == nil {
+		t.Fatalf("state event should build the remote tabs and fields, got %s", tabTitles(tui))
+	}
+	port := build.FieldHandlers[1]
+
+	tui.primaryDaemon().handleStatePatchEvent(`{
+		"upsert":[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"label":"Port (in use)","value":"8081"},
+		          {"tab_title":"BUILD","handler_name":"Build","handler_type":2,"label":"Build"}],
+		"remove":[{"tab_title":"BUILD","handler_name":"Mode"},{"tab_title":"DEPLOY","handler_name":"Deploy"}]}`)
+
+	if len(build.FieldHandlers) != 2 || build.FieldHandlers[0] != port {
+		t.Fatalf("patch should remove Mode, keep Port and add Build, got %d fields", len(build.FieldHandlers))
+	}
+	if port.handler.Label() != "Port (in use)" || port.Value() != "8081" {
+		t.Errorf("patch should update Port in place, got %q %q", port.handler.Label(), port.Value())
+	}
+	if tui.tabByTitle("DEPLOY") != nil {
+		t.Error("a tab whose last handler is removed should be removed")
+	}
+
+	tui.primaryDaemon().handleStateEvent(`[]`)
+	if len(build.FieldHandlers) != 2 {
+		t.Error("an empty snapshot must not wipe the fields")
+	}
+}
+
+func TestStateEvents_RefetchOnReconnect(t *testing.T) {
+	var stateFetches, connections atomic.Int32
+	refetched := make(chan struct{})
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mcp" {
+			if stateFetches.Add(1) == 2 {
+				close(refetched)
+			}
+			io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":[]}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		if connections.Add(1) == 1 {
+			io.WriteString(w, "retry: 10\n\n")
+			return // drop the stream
+		}
+		w.(http.Flusher).Flush() // connected: the client refetches the state
+		select {
+		case <-refetched:
+		case <-r.Context().Done():
+			return
+		}
+		io.WriteString(w, `data: {"id":"1","timestamp":"1","content":"live","tab_title":"LOGS","handler_type":4}`+"\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer daemon.Close()
+
+	config := &TuiConfig{ClientMode: true, ClientURL: daemon.URL + "/logs"}
+	tui := NewTUI(config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tui.sseWg.Add(1)
+	go tui.startSSEClient(config.ClientURL, ctx)
+
+	// The client stays live once the line of the second stream is shown
+	waitFor(t, "the line of the live stream", func() bool {
+		var shown bool
+		tui.runOnUI(func() { shown = tui.tabByTitle("LOGS") != nil })
+		return shown
+	})
+	if got := stateFetches.Load(); got != 2 {
+		t.Errorf("state should not be refetched while live, got %d fetches", got)
+	}
+}
+
+func TestStateEvents_PatchBeforeFirstSnapshot(t *testing.T) {
+	tui := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	d := tui.primaryDaemon()
+
+	d.handleStatePatchEvent(`{"upsert":[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"label":"Port","value":"8081"}]}`)
+	if tui.tabByTitle("BUILD") != nil {
+		t.Fatal("a patch should wait for the first snapshot")
+	}
+
+	d.applyState([]StateEntry{
+		{TabTitle: "BUILD", HandlerName: "Mode", HandlerType: HandlerTypeEdit, Label: "Mode", Value: "dev"},
+		{TabTitle: "BUILD", HandlerName: "Port", HandlerType: HandlerTypeEdit, Label: "Port", Value: "8080"},
+	})
+	build := tui.tabByTitle("BUILD")
+	if build == nil || len(build.FieldHandlers) != 2 {
+		t.Fatalf("the snapshot should build both fields, got %s", tabTitles(tui))
+	}
+	if v := build.FieldHandlers[1].Value(); v != "8081" {
+		t.Errorf("the queued patch should be merged into the snapshot, got %q", v)
+	}
+
+	empty := NewTUI(&TuiConfig{ClientMode: true, ClientURL: "http://localhost:1/logs"})
+	empty.primaryDaemon().handleStatePatchEvent(`{"upsert":[{"tab_title":"BUILD","handler_name":"Port","handler_type":1,"value":"1"}]}`)
+	empty.primaryDaemon().applyEmptyState()
+	if section := empty.tabByTitle("BUILD"); section == nil || len(section.FieldHandlers) != 1 {
+		t.Error("an empty first snapshot should apply the queued patches")
+	}
+}
@@ -1,7 +1,9 @@
 package devtui
 
 import (
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	. "github.com/tinywasm/fmt"
@@ -28,6 +30,10 @@ type tabContent struct {
 	RawHandlerName string      // Unformatted raw handler name used for matching/updating
 	handlerColor   string      // NEW: Handler-specific color for message formatting
 	handlerType    handlerType // NEW: Type of handler (Interactive, Display, etc.) for formatting
+
+	animationFrame bool          // LogOpen animation tick (not a new message, not recorded in the history)
+	openedAt       string        // timestamp of the LogOpen of the handler's running operation ("" = none)
+	duration       time.Duration // LogOpen to LogClose time, set on the close line (see operation_timing.go)
 }
 
 // tabSection represents a tab section in the TUI with configurable fields and content
@@ -47,6 +53,42 @@ type tabSection struct {
 
 	// Animation state management
 	animationStopChans map[string]chan struct{}
+	operationStarts    map[string]string          // handler name -> LogOpen timestamp (see timestamps.go)
+	operationDurations map[string][]time.Duration // handler name -> closed operation durations
+	timedHandlers      []string                   // handler names in first-timed order
+
+	// Grouped view state (see handler_groups.go)
+	groupedView     bool            // render messages under collapsible handler headers
+	collapsedGroups map[string]bool // handler name -> collapsed
+	groupOrder      []string        // handler names in first-seen order
+	selectedGroup   int             // index of the selected header
+
+	remote     bool          // client mode tab created from the daemon state (see remote_tabs.go)
+	daemon     *remoteDaemon // daemon that created the remote tab (see remote_daemon.go)
+	source     tabSource     // what created the remote tab, which decides what removes it
+	removed    atomic.Bool   // remote tab removed from TabSections (see logTab)
+	isOverview bool          // built-in OVERVIEW tab: ContentView renders the handler table (see overview.go)
+
+	// Unread/error badges (see tab_badges.go)
+	unread   map[string]MessageType // tabContent.Id -> type, for messages received while inactive
+	lastType MessageType            // type of the newest message
+
+	// Tracked message history (see content_history.go)
+	history  map[string][]tabContent // tabContent.Id -> previous versions, oldest first
+	expanded map[string]bool         // tabContent.Id -> history shown under the line
+
+	// Pinned messages (see pins.go)
+	pins     []tabContent // copies of the pinned messages, in pin order
+	pinFocus string       // pinKey of the bookmark the log is scrolled to ("" = follow new messages)
+}
+
+// contentsSnapshot returns a copy of tabContents so callers can render without holding the lock.
+func (ts *tabSection) contentsSnapshot() []tabContent {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	contents := make([]tabContent, len(ts.tabContents))
+	copy(contents, ts.tabContents)
+	return contents
 }
 
 // getWritingHandler busca un handler por nombre en el slice thread-safe
@@ -98,14 +140,19 @@ func (t *tabSection) addNewContent(msgType MessageType, content string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.tabContents = append(t.tabContents, t.tui.createTabContent(content, msgType, t, "", "", "", handlerTypeLoggable))
-	if len(t.tabContents) > 500 {
-		t.tabContents = t.tabContents[len(t.tabContents)-500:]
-	}
+	t.trimContents()
 }
 
 // NEW: updateOrAddContentWithHandler updates existing content by handler name (trackingID)
 // Returns true if content was updated, false if new content was added
 func (t *tabSection) updateOrAddContentWithHandler(msgType MessageType, content string, handlerName string, trackingID string, handlerColor string, hType handlerType) (updated bool, newContent tabContent) {
+	return t.updateOrAddContent(msgType, content, handlerName, trackingID, handlerColor, hType, false, 0)
+}
+
+// updateOrAddContent is updateOrAddContentWithHandler; frame marks LogOpen animation
+// ticks, which replace the tracked line without being recorded in its history, and
+// duration is the LogOpen to LogClose time of a close line (0 otherwise).
+func (t *tabSection) updateOrAddContent(msgType MessageType, content string, handlerName string, trackingID string, handlerColor string, hType handlerType, frame bool, duration time.Duration) (updated bool, newContent tabContent) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -113,9 +160,13 @@ func (t *tabSection) updateOrAddContentWithHandler(msgType MessageType, content
 	if trackingID != "" {
 		for i := range t.tabContents {
 			if t.tabContents[i].RawHandlerName == trackingID {
+				t.recordHistory(t.tabContents[i])
+				t.tabContents[i].animationFrame = frame
+				t.tabContents[i].duration = duration
 				// Update existing content
 				t.tabContents[i].Content = content
 				t.tabContents[i].Type = msgType
+				t.tabContents[i].openedAt = t.operationStarts[trackingID]
 				// Actualizar timestamp usando GetNewID directamente
 				if t.tui.id != nil {
 					t.tabContents[i].Timestamp = t.tui.id.GetNewID()
@@ -138,12 +189,16 @@ func (t *tabSection) updateOrAddContentWithHandler(msgType MessageType, content
 
 	// If not found or no trackingID, add new content
 	newContent = t.tui.createTabContent(content, msgType, t, handlerName, trackingID, handlerColor, hType)
+	newContent.animationFrame = frame
+	newContent.duration = duration
+	newContent.openedAt = t.operationStarts[handlerName]
 	t.tabContents = append(t.tabContents, newContent)
+	if !slices.Contains(t.groupOrder, handlerName) {
+		t.groupOrder = append(t.groupOrder, handlerName)
+	}
 
 	// Keep only last 500 messages to prevent memory issues and slow rendering
-	if len(t.tabContents) > 500 {
-		t.tabContents = t.tabContents[len(t.tabContents)-500:]
-	}
+	t.trimContents()
 
 	return false, newContent
 }
@@ -161,6 +216,8 @@ func (t *DevTUI) NewTabSection(title, description string) any {
 		SectionDescription: description,
 		tui:                t,
 		animationStopChans: make(map[string]chan struct{}),
+		groupedView:        t.GroupedView,
+		collapsedGroups:    make(map[string]bool),
 	}
 
 	// Automatically add to TabSections and initialize
@@ -195,6 +252,8 @@ func (t *DevTUI) notifyTabActive(tabIndex int) {
 	}
 
 	tab := t.TabSections[tabIndex]
+	tab.markRead()
+
 	tab.mu.RLock()
 	defer tab.mu.RUnlock()
 
@@ -257,6 +316,15 @@ func (ts *tabSection) stopAnimation(handlerName string) {
 
 // startAnimation starts a new auto-animation for a given handler
 func (ts *tabSection) startAnimation(handlerName, baseMessage string, msgType MessageType, color string) {
+	ts.animate(handlerName, baseMessage, func(content string) {
+		// Update the same line (using handlerName as trackingID)
+		ts.tui.sendAnimationFrame(content, msgType, ts, handlerName, color)
+	})
+}
+
+// animate runs the animation of handlerName: sendFrame gets baseMessage with
+// 0-3 dots every 400ms until stopAnimation.
+func (ts *tabSection) animate(handlerName, baseMessage string, sendFrame func(content string)) {
 	// First stop any existing animation
 	ts.stopAnimation(handlerName)
 
@@ -279,8 +347,7 @@ func (ts *tabSection) startAnimation(handlerName, baseMessage string, msgType Me
 				if len(dots) > 6 { // Max 3 dots " . . ."
 					dots = ""
 				}
-				// Update the same line (using handlerName as trackingID)
-				ts.tui.sendMessageWithHandler(baseMessage+dots, msgType, ts, handlerName, handlerName, color, handlerTypeLoggable)
+				sendFrame(baseMessage + dots)
 			}
 		}
 	}()
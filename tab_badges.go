@@ -0,0 +1,85 @@
+package devtui
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Unread/error badges: messages that land in an inactive tab are remembered per
+// tabContent.Id (tracked updates keep their Id, so one handler line counts once)
+// until the tab is activated again. headerView renders them next to the pagination.
+
+// markUnread records a message that arrived while its tab was not active.
+func (ts *tabSection) markUnread(tc tabContent) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.unread == nil {
+		ts.unread = make(map[string]MessageType)
+	}
+	ts.unread[tc.Id] = tc.Type
+}
+
+// markRead clears the unread messages of the tab (called when it becomes active).
+func (ts *tabSection) markRead() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.unread = nil
+}
+
+// setLastType records the type of the newest message of the tab.
+func (ts *tabSection) setLastType(mt MessageType) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.lastType = mt
+}
+
+// unreadCounts returns the number of unread messages, how many of them are errors
+// and whether the newest message of the tab is an error.
+func (ts *tabSection) unreadCounts() (unread, errors int, lastIsError bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	for _, mt := range ts.unread {
+		if mt == Msg.Error {
+			errors++
+		}
+	}
+	return len(ts.unread), errors, ts.lastType == Msg.Error
+}
+
+// tabBadge renders the badge of a single tab: "•3" unread, "✖1" unread errors.
+// Tabs whose newest message is an error are rendered in the error style.
+// Returns "" when there is nothing to show.
+func (h *DevTUI) tabBadge(ts *tabSection) string {
+	unread, errors, lastIsError := ts.unreadCounts()
+	if unread == 0 && !lastIsError {
+		return ""
+	}
+	badge := ""
+	if unread > 0 {
+		badge += Sprintf("•%d", unread)
+	}
+	if errors > 0 {
+		badge += Sprintf("✖%d", errors)
+	}
+	if badge == "" {
+		badge = "✖"
+	}
+	if lastIsError {
+		return h.errStyle.Render(badge)
+	}
+	return h.timeStyle.Render(badge)
+}
+
+// tabBadges renders "2•3", "4•1✖1"... for every inactive tab with unread
+// messages or whose newest message is an error.
+func (h *DevTUI) tabBadges() []string {
+	var parts []string
+	for i, ts := range h.TabSections {
+		if i == h.activeTab {
+			continue
+		}
+		if badge := h.tabBadge(ts); badge != "" {
+			parts = append(parts, h.timeStyle.Render(Sprintf("%d", i+1))+badge)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,64 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+// deliver sends a message and feeds the resulting channel message through Update,
+// the same path the running program uses.
+func deliver(h *DevTUI, ts *tabSection, content string, mt MessageType, handler string) {
+	h.sendMessageWithHandler(content, mt, ts, handler, handler, "", handlerTypeLoggable)
+	h.Update(channelMsg(<-h.tabContentsChan))
+}
+
+func TestTabBadges_CountUnreadAndErrors(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 100, 20
+	h.NewTabSection("MAIN", "Active tab")
+	build := h.NewTabSection("BUILD", "Background tab").(*tabSection)
+	h.activeTab = 0
+
+	deliver(h, build, "compiling", Msg.Info, "Compiler")
+	deliver(h, build, "compiling pkg/a", Msg.Info, "Compiler") // tracked update: same line
+	deliver(h, build, "tests failed", Msg.Error, "Tester")
+
+	unread, errors, lastIsError := build.unreadCounts()
+	if unread != 2 {
+		t.Errorf("expected 2 unread lines, got %d", unread)
+	}
+	if errors != 1 {
+		t.Errorf("expected 1 unread error, got %d", errors)
+	}
+	if !lastIsError {
+		t.Error("expected BUILD to be flagged as ending in an error")
+	}
+
+	header := h.headerView()
+	if !strings.Contains(header, "•2") || !strings.Contains(header, "✖1") {
+		t.Errorf("header should show unread and error badges, got %q", header)
+	}
+
+	// Switching to the tab marks it read
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyTab})
+	if unread, _, _ := build.unreadCounts(); unread != 0 {
+		t.Errorf("expected unread to be cleared after activating the tab, got %d", unread)
+	}
+}
+
+func TestTabBadges_AnimationFramesAreNotUnread(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.NewTabSection("MAIN", "Active tab")
+	build := h.NewTabSection("BUILD", "Background tab").(*tabSection)
+	h.activeTab = 0
+
+	h.sendAnimationFrame("Deploying . .", Msg.Info, build, "Deployer", "")
+	h.Update(channelMsg(<-h.tabContentsChan))
+
+	if unread, _, _ := build.unreadCounts(); unread != 0 {
+		t.Errorf("animation frames must not count as unread, got %d", unread)
+	}
+}
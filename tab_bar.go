@@ -0,0 +1,116 @@
+package devtui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	. "github.com/tinywasm/fmt"
+)
+
+// Tab strip (TuiConfig.TabBar): a second header line listing every tab title,
+// plus Alt+1..9 / F1..F12 to jump directly to a tab. The jump keys are reserved
+// in the ShortcutRegistry so handler shortcuts cannot take them.
+
+// tabJumpOwner is the owner recorded for the reserved tab jump keys.
+const tabJumpOwner = "tab jump"
+
+// reserveTabJumpKeys reserves the direct tab jump keys in the shortcut registry.
+func reserveTabJumpKeys(sr *ShortcutRegistry) {
+	for i := 1; i <= 9; i++ {
+		sr.Reserve(Sprintf("alt+%d", i), tabJumpOwner)
+	}
+	for i := 1; i <= 12; i++ {
+		sr.Reserve(Sprintf("f%d", i), tabJumpOwner)
+	}
+}
+
+// tabJumpIndex returns the tab index targeted by Alt+1..9 or F1..F12 (-1 if none).
+func tabJumpIndex(msg tea.KeyMsg) int {
+	// bubbletea key types count down from KeyF1 to KeyF12
+	if i := int(tea.KeyF1 - msg.Type); i >= 0 && i < 12 {
+		return i
+	}
+	if msg.Alt && msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+		if r := msg.Runes[0]; r >= '1' && r <= '9' {
+			return int(r - '1')
+		}
+	}
+	return -1
+}
+
+// jumpToTab activates the tab at index, keeping its selected field.
+// Returns false when the index is out of range.
+func (h *DevTUI) jumpToTab(index int) bool {
+	if index < 0 || index >= len(h.TabSections) {
+		return false
+	}
+	if index != h.activeTab {
+		h.navigateTo(index, -1)
+		h.updateViewport()
+	}
+	return true
+}
+
+// tabBarView renders the tab strip: "1 BUILD  2 DEPLOY•3  3 LOGS" with the active
+// tab highlighted. Titles are truncated so that every tab fits the viewport width.
+func (h *DevTUI) tabBarView() string {
+	labels := h.tabBarLabels()
+	if len(labels) == 0 {
+		return ""
+	}
+	bar := Convert(labels).Join(" ").String()
+	if width := h.tabBarWidth(); lipgloss.Width(bar) > width {
+		bar = lipgloss.NewStyle().MaxWidth(width).Render(bar)
+	}
+	return bar
+}
+
+// tabBarWidth returns the width available to the tab strip.
+func (h *DevTUI) tabBarWidth() int {
+	if h.viewport.Width <= 0 {
+		return UIColumnWidth * 4
+	}
+	return h.viewport.Width
+}
+
+// tabBarLabels renders one label per tab; tabBarView joins them with one space.
+func (h *DevTUI) tabBarLabels() []string {
+	total := len(h.TabSections)
+	if total == 0 {
+		return nil
+	}
+
+	// Budget per tab: separator (1) + "N " prefix, the remainder goes to the title
+	perTab := h.tabBarWidth()/total - 1
+	labels := make([]string, total)
+	for i, ts := range h.TabSections {
+		prefix := Sprintf("%d ", i+1)
+		badge := ""
+		if i != h.activeTab {
+			badge = h.tabBadge(ts)
+		}
+		titleWidth := perTab - lipgloss.Width(prefix) - lipgloss.Width(badge) - 2
+		title := ts.Title
+		if titleWidth < lipgloss.Width(title) {
+			title = Convert(title).Truncate(max(titleWidth, 1), 0).String()
+		}
+		if i == h.activeTab {
+			labels[i] = h.headerTitleStyle.Render(prefix + title)
+		} else {
+			labels[i] = h.textContentStyle.Padding(0, 1).Render(prefix+title) + badge
+		}
+	}
+	return labels
+}
+
+// tabBarHit returns the tab under column x of the tab strip (-1 if none).
+func (h *DevTUI) tabBarHit(x int) int {
+	start := 0
+	for i, label := range h.tabBarLabels() {
+		end := start + lipgloss.Width(label)
+		if x >= start && x < end {
+			return i
+		}
+		start = end + 1 // separator
+	}
+	return -1
+}
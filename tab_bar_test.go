@@ -0,0 +1,74 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTabBar_RendersEveryTitle(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.TabBar = true
+	h.viewport.Width, h.viewport.Height = 120, 20
+	for _, title := range []string{"BUILD", "DEPLOY", "LOGS", "CONFIG"} {
+		h.NewTabSection(title, "")
+	}
+
+	header := h.headerView()
+	for i, title := range []string{"1 BUILD", "2 DEPLOY", "3 LOGS", "4 CONFIG"} {
+		if !strings.Contains(header, title) {
+			t.Errorf("tab %d: header should contain %q, got %q", i, title, header)
+		}
+	}
+
+	// Narrow terminal: titles are truncated but the strip stays within the width
+	h.viewport.Width = 30
+	if w := maxLineWidth(h.tabBarView()); w > 30 {
+		t.Errorf("tab strip should fit 30 columns, got %d", w)
+	}
+}
+
+func TestTabBar_JumpKeys(t *testing.T) {
+	h := DefaultTUIForTest()
+	for _, title := range []string{"A", "B", "C"} {
+		h.NewTabSection(title, "")
+	}
+
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}, Alt: true})
+	if h.activeTab != 2 {
+		t.Errorf("Alt+3 should jump to tab 2, got %d", h.activeTab)
+	}
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyF2})
+	if h.activeTab != 1 {
+		t.Errorf("F2 should jump to tab 1, got %d", h.activeTab)
+	}
+	// Out of range keys are ignored
+	h.handleKeyboard(tea.KeyMsg{Type: tea.KeyF9})
+	if h.activeTab != 1 {
+		t.Errorf("F9 without a 9th tab should not move, got %d", h.activeTab)
+	}
+}
+
+func TestTabBar_JumpKeysAreReserved(t *testing.T) {
+	h := DefaultTUIForTest()
+	err := h.shortcutRegistry.TryRegister("alt+1", &ShortcutEntry{Key: "alt+1", HandlerName: "Builder"})
+	if err == nil {
+		t.Fatal("registering a reserved tab jump key should fail")
+	}
+	if _, exists := h.shortcutRegistry.Get("alt+1"); exists {
+		t.Error("reserved key must not be registered")
+	}
+	if owner, ok := h.shortcutRegistry.IsReserved("f12"); !ok || owner != tabJumpOwner {
+		t.Errorf("expected f12 reserved for %q, got %q (%v)", tabJumpOwner, owner, ok)
+	}
+}
+
+func maxLineWidth(s string) int {
+	w := 0
+	for _, line := range strings.Split(s, "\n") {
+		w = max(w, lipgloss.Width(line))
+	}
+	return w
+}
@@ -0,0 +1,106 @@
+package devtui
+
+import (
+	"time"
+
+	. "github.com/tinywasm/fmt"
+	tinytime "github.com/tinywasm/time"
+)
+
+// TimestampMode selects how message timestamps are rendered
+// (TuiConfig.TimestampMode, cycled at runtime with the "timestamps" keymap action, Alt+T).
+type TimestampMode int
+
+const (
+	TimestampClock    TimestampMode = iota // "15:04:05" (default)
+	TimestampMillis                        // "15:04:05.123"
+	TimestampDateTime                      // "2006-01-02 15:04:05", unambiguous across midnight
+	TimestampRelative                      // "12s ago", refreshed every second
+	TimestampElapsed                       // "+4.2s" since the handler's LogOpen (or the session start)
+)
+
+var timestampModeNames = [...]string{"clock", "millis", "date-time", "relative", "elapsed"}
+
+func (m TimestampMode) String() string {
+	if m < 0 || int(m) >= len(timestampModeNames) {
+		return "unknown"
+	}
+	return timestampModeNames[m]
+}
+
+// next returns the mode following m, wrapping to TimestampClock.
+func (m TimestampMode) next() TimestampMode {
+	return (m + 1) % TimestampMode(len(timestampModeNames))
+}
+
+// cycleTimestampMode switches to the next display mode and reports it through Logger.
+func (h *DevTUI) cycleTimestampMode() {
+	h.timestampMode = h.timestampMode.next()
+	if h.Logger != nil {
+		h.Logger("Timestamp mode:", h.timestampMode.String())
+	}
+	h.updateViewport()
+}
+
+// beginOperation records the start of a LogOpen operation of handlerName: following
+// messages of the handler carry it until endOperation (LogClose).
+func (ts *tabSection) beginOperation(handlerName string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.operationStarts == nil {
+		ts.operationStarts = make(map[string]string)
+	}
+	ts.operationStarts[handlerName] = Convert(time.Now().UnixNano()).String()
+}
+
+// endOperation forgets the LogOpen start of handlerName.
+func (ts *tabSection) endOperation(handlerName string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	delete(ts.operationStarts, handlerName)
+}
+
+// formatTimestamp renders timestamp (see tabContent.Timestamp) in the current mode.
+// openedAt is the LogOpen start of the message's operation ("" = none).
+func (h *DevTUI) formatTimestamp(timestamp, openedAt string) string {
+	if timestamp == "" {
+		return "--:--:--"
+	}
+	t, ok := timestampTime(timestamp)
+	if !ok {
+		// fallback "HH:MM:SS" timestamps can only be shown as they are
+		return tinytime.FormatTime(timestamp)
+	}
+
+	switch h.timestampMode {
+	case TimestampMillis:
+		return tinytime.FormatTime(t.UnixNano()) + Sprintf(".%03d", t.Nanosecond()/int(time.Millisecond))
+	case TimestampDateTime:
+		return tinytime.FormatDateTime(t.UnixNano())
+	case TimestampRelative:
+		return padRight(formatAge(time.Since(t))+" ago", 8)
+	case TimestampElapsed:
+		start := h.startedAt
+		if opened, ok := timestampTime(openedAt); ok {
+			start = opened
+		}
+		return padRight("+"+formatElapsed(t.Sub(start)), 8)
+	default:
+		return tinytime.FormatTime(t.UnixNano()) // unixid ".suffix" stripped by timestampTime
+	}
+}
+
+// formatElapsed renders d with one decimal below a minute ("4.2s"), then "3m05s" and "2h07m".
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return Sprintf("%d.%ds", int(d/time.Second), int(d%time.Second/(100*time.Millisecond)))
+	case d < time.Hour:
+		return Sprintf("%dm%02ds", int(d/time.Minute), int(d%time.Minute/time.Second))
+	default:
+		return Sprintf("%dh%02dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	}
+}
@@ -0,0 +1,109 @@
+package devtui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/tinywasm/fmt"
+)
+
+func nanoStamp(t time.Time) string {
+	return Convert(t.UnixNano()).String()
+}
+
+func TestTimestampModes(t *testing.T) {
+	h := DefaultTUIForTest()
+	at := time.Date(2026, 3, 1, 23, 59, 58, 250*int(time.Millisecond), time.Local)
+	stamp := nanoStamp(at)
+
+	h.timestampMode = TimestampMillis
+	if got := h.formatTimestamp(stamp, ""); !strings.HasSuffix(got, ".250") || len(got) != 12 {
+		t.Errorf("millis mode: got %q", got)
+	}
+	if got := h.formatTimestamp(stamp+".1", ""); !strings.HasSuffix(got, ".250") || len(got) != 12 {
+		t.Errorf("millis mode should ignore a unixid suffix, got %q", got)
+	}
+
+	h.timestampMode = TimestampDateTime
+	if got := h.formatTimestamp(stamp, ""); len(got) != 19 || !strings.Contains(got, "2026-03-0") {
+		t.Errorf("date-time mode should include the date, got %q", got)
+	}
+
+	h.timestampMode = TimestampRelative
+	if got := h.formatTimestamp(nanoStamp(time.Now().Add(-12*time.Second)), ""); !strings.HasPrefix(got, "12s ago") {
+		t.Errorf("relative mode: got %q", got)
+	}
+
+	h.timestampMode = TimestampElapsed
+	if got := h.formatTimestamp(stamp, nanoStamp(at.Add(-4200*time.Millisecond))); strings.TrimSpace(got) != "+4.2s" {
+		t.Errorf("elapsed mode should measure from the LogOpen, got %q", got)
+	}
+
+	h.timestampMode = TimestampClock
+	if got := h.formatTimestamp("", ""); got != "--:--:--" {
+		t.Errorf("missing timestamp: got %q", got)
+	}
+}
+
+func TestTimestampElapsed_SinceLogOpen(t *testing.T) {
+	h := NewTUI(&TuiConfig{Logger: func(...any) {}, TimestampMode: TimestampElapsed})
+	tab := h.NewTabSection("DEPLOY", "").(*tabSection)
+	deployer := &testLoggable{name: "Deployer"}
+	h.AddHandler(deployer, "", tab)
+
+	deployer.logFunc(LogOpen, "Deploying")
+	opened := tab.contentsSnapshot()[0].openedAt
+	if opened == "" {
+		t.Fatal("LogOpen should record the operation start on the line")
+	}
+	deployer.logFunc(LogClose, "Deployment complete")
+	if got := tab.contentsSnapshot()[0].openedAt; got != opened {
+		t.Errorf("LogClose line should keep the LogOpen start, got %q want %q", got, opened)
+	}
+
+	deployer.logFunc("next step")
+	if got := tab.contentsSnapshot()[0].openedAt; got != "" {
+		t.Errorf("messages after LogClose should not belong to the operation, got %q", got)
+	}
+}
+
+func TestTimestampMode_CycleKey(t *testing.T) {
+	var logged []string
+	h := NewTUI(&TuiConfig{Logger: func(messages ...any) { logged = append(logged, Sprint(messages[len(messages)-1])) }})
+	h.NewTabSection("TESTS", "")
+	for _, want := range []TimestampMode{TimestampMillis, TimestampDateTime, TimestampRelative, TimestampElapsed, TimestampClock} {
+		h.handleKeyboard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}, Alt: true})
+		if h.timestampMode != want {
+			t.Fatalf("Alt+T: got mode %s, want %s", h.timestampMode, want)
+		}
+		if last := logged[len(logged)-1]; !strings.Contains(last, want.String()) {
+			t.Errorf("Alt+T should log the new mode, got %q", last)
+		}
+	}
+}
+
+func TestTimestampRelative_TickKeepsScroll(t *testing.T) {
+	h := DefaultTUIForTest()
+	h.viewport.Width, h.viewport.Height = 80, 5
+	tab := h.NewTabSection("LOGS", "").(*tabSection)
+	h.activeTab = tab.Index
+	for i := range 20 {
+		h.sendMessageWithHandler(Sprintf("line %d", i), Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	}
+	h.timestampMode = TimestampRelative
+	h.updateViewport()
+	h.viewport.SetYOffset(3)
+
+	h.Update(tickMsg(time.Now()))
+	if h.viewport.YOffset != 3 {
+		t.Errorf("a tick should keep the scroll position, got offset %d", h.viewport.YOffset)
+	}
+	h.viewport.GotoBottom()
+	h.sendMessageWithHandler("line 20", Msg.Info, tab, "", "", "", handlerTypeLoggable)
+	h.Update(tickMsg(time.Now()))
+	if !h.viewport.AtBottom() {
+		t.Error("a viewport at the bottom should keep following it")
+	}
+}
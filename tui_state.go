@@ -2,8 +2,10 @@ package devtui
 
 import (
 	"context"
+	"io"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,6 +36,8 @@ type DevTUI struct {
 	editModeActivated bool          // global flag to edit config
 
 	shortcutRegistry *ShortcutRegistry // NEW: Global shortcut key registry
+	keymap           Keymap            // built-in key bindings (DefaultKeymap + keymap file)
+	pendingChord     []string          // strokes typed so far of a chord shortcut ("g" of "g d")
 
 	currentTime     string
 	tabContentsChan chan tabContent
@@ -45,9 +49,34 @@ type DevTUI struct {
 
 	cursorVisible bool // for blinking effect
 
+	contentAnchor  int      // content line updateViewport keeps visible instead of going to bottom (-1 = none)
+	contentLineIds []string // tabContent.Id owning each rendered content line of the flat view ("" = none)
+
+	mouseEnabled bool // mouse capture active (TuiConfig.Mouse, toggled with the "mouse" keymap action)
+
+	overviewSelected int // selected row in the OVERVIEW tab
+
+	palette *commandPalette // open Ctrl+P command palette (nil = closed)
+	help    *helpOverlay    // open "?" shortcut help overlay (nil = closed)
+
+	copyMode     *copySelection // message selection of copy mode (nil = off)
+	clipboardOut io.Writer      // where OSC 52 sequences are written (the program's terminalOutput; nil = os.Stdout)
+
+	timestampMode TimestampMode // current timestamp display mode (starts at TuiConfig.TimestampMode)
+	startedAt     time.Time     // session start, reference of TimestampElapsed outside LogOpen operations
+	showTimings   bool          // operation timing stats panel (starts at TuiConfig.TimingStats)
+
 	isShuttingDown atomic.Bool
 	sseCancel      context.CancelFunc // cancels SSE HTTP request context
-	sseWg          sync.WaitGroup     // tracks SSE goroutine
+	sseWg          sync.WaitGroup     // tracks SSE goroutines
+
+	uiRunning atomic.Bool   // the tea program runs Update (see runOnUI)
+	uiQuit    chan struct{} // closed when the tea program returned
+	uiMu      sync.Mutex    // serializes runOnUI calls while no program runs
+
+	daemons []*remoteDaemon // client mode endpoints, ClientURL or TuiConfig.Endpoints (see remote_daemon.go)
+
+	headless *headlessServer // headless mode HTTP server (nil = terminal UI)
 }
 
 type TuiConfig struct {
@@ -70,4 +99,23 @@ type TuiConfig struct {
 	ClientMode bool   // true if it should listen to SSE
 	ClientURL  string // e.g. http://localhost:3030/logs
 	APIKey     string // Bearer token for secured daemon; set by app, empty = open/local
+
+	Endpoints []Endpoint // client mode daemons watched at once, each with its own SSE connection and actions (replaces ClientURL/APIKey; names and URLs must be set and unique)
+	MergeTabs bool       // show same-titled tabs of every endpoint as one tab instead of "<Name>/<TAB>"
+
+	Headless  bool   // run without a terminal, serving /logs and /mcp to client mode instances (see headless.go)
+	ServeAddr string // headless mode listen address, e.g. ":3030" (default "127.0.0.1:3030"; APIKey, when set, is required from clients)
+
+	GroupedView bool // start every tab in the collapsible handler-group view (toggle per tab with Ctrl+G)
+	Overview    bool // add the built-in OVERVIEW tab summarising every handler's last state
+	Mouse       bool // capture the mouse: click tabs/pagination/lines, wheel scroll (toggle capture with Ctrl+O to select text)
+	TabBar      bool // show a tab strip with every tab title under the header (Alt+1..9 / F1..F12 jump keys always work)
+
+	TimestampMode TimestampMode // TimestampClock (default), TimestampMillis, TimestampDateTime, TimestampRelative or TimestampElapsed (cycle with Alt+T)
+
+	TimingStats bool // show the per-handler LogOpen/LogClose duration stats (last, average, p95) above each log (toggle with Alt+S)
+
+	KeymapFile string // JSON keymap overriding built-in keys and handler shortcuts (default: <user config dir>/<AppName>/keymap.json)
+
+	ShortcutConflictPolicy ShortcutConflictPolicy // two handlers claiming the same key: ShortcutLastWins (default), ShortcutFirstWins or ShortcutConflictError
 }
@@ -0,0 +1,54 @@
+package devtui
+
+// UI goroutine: Update and View read TabSections, the active tab and the
+// shortcut registry indexes without locks, so work from other goroutines that
+// changes them (SSE clients creating or removing remote tabs, DispatchAction)
+// runs on the goroutine running the tea program, through runOnUI. Code already
+// running on the UI goroutine (Update, handlers it calls) changes them in place
+// and must not call runOnUI: it would wait for itself.
+
+// uiFuncMsg carries a runOnUI function to the UI goroutine.
+type uiFuncMsg struct {
+	fn   func()
+	done chan struct{}
+}
+
+// runOnUI runs fn on the UI goroutine and waits for it. It must be called from
+// another goroutine. While the program is not running (tests, headless mode,
+// after quit), fn runs on the calling goroutine, serialized by uiMu.
+func (h *DevTUI) runOnUI(fn func()) {
+	if h.uiRunning.Load() {
+		done := make(chan struct{})
+		h.tea.Send(uiFuncMsg{fn: fn, done: done})
+		select {
+		case <-done:
+			return
+		case <-h.uiQuit:
+		}
+		select {
+		case <-done:
+			return // ran before the program stopped
+		default:
+		}
+	}
+	h.uiMu.Lock()
+	defer h.uiMu.Unlock()
+	fn()
+}
+
+// startUI marks the start of the UI goroutine (see Init): runOnUI calls are
+// sent to the program from now on.
+func (h *DevTUI) startUI() {
+	h.uiRunning.Store(true)
+}
+
+// stopUI marks the end of the UI goroutine, once the tea program returned:
+// pending runOnUI calls run on their own goroutine.
+func (h *DevTUI) stopUI() {
+	h.uiRunning.Store(false)
+	select {
+	case <-h.uiQuit:
+	default:
+		close(h.uiQuit)
+	}
+}
@@ -61,12 +61,23 @@ func (h *DevTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Only update the viewport if the message belongs to the currently active tab
 		if tc.tabSection.Index == h.activeTab {
 			h.updateViewport()
+		} else if !tc.animationFrame {
+			// Background tab: remember it for the header badges
+			tc.tabSection.markUnread(tc)
+		}
+		if !tc.animationFrame {
+			tc.tabSection.setLastType(tc.Type)
 		}
 
 	case refreshTabMsg: // Handle manual refresh requests from external tools
 		// Update viewport for the currently active tab
 		h.updateViewport()
 
+	case uiFuncMsg: // work from another goroutine (see runOnUI)
+		msg.fn()
+		close(msg.done)
+		h.refreshViewport()
+
 	case tea.WindowSizeMsg: // update the viewport size
 
 		headerHeight := lipgloss.Height(h.headerView())
@@ -81,8 +92,9 @@ func (h *DevTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// here.
 			h.viewport = viewport.New(msg.Width, msg.Height-verticalMarginHeight)
 			h.viewport.YPosition = headerHeight
-			// Disable mouse wheel to enable terminal text selection
-			h.viewport.MouseWheelEnabled = false
+			// Mouse wheel only in mouse mode: otherwise keep terminal text selection
+			h.viewport.MouseWheelEnabled = h.mouseEnabled
+			h.keymap.applyViewportKeys(&h.viewport)
 			h.viewport.SetContent(h.ContentView())
 			h.ready = true
 		} else {
@@ -93,11 +105,19 @@ func (h *DevTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tickMsg: // update the time every second
 		h.currentTime = tinytime.FormatTime(tinytime.Now())
 		cmds = append(cmds, h.tickEverySecond())
+		// OVERVIEW shows "time since last update": keep it ticking
+		// as do relative ("12s ago") timestamps
+		if h.activeTab < len(h.TabSections) && (h.TabSections[h.activeTab].isOverview || h.timestampMode == TimestampRelative) {
+			h.refreshViewport()
+		}
 
 	case cursorTickMsg: // toggle cursor for blinking effect
 		h.cursorVisible = !h.cursorVisible
 		cmds = append(cmds, h.cursorTick())
 
+	case tea.MouseMsg: // TuiConfig.Mouse: clicks (the viewport scrolls on wheel below)
+		h.handleMouse(msg)
+
 	case tea.FocusMsg:
 		h.focused = true
 	case tea.BlurMsg:
@@ -116,9 +136,33 @@ func (h *DevTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (h *DevTUI) updateViewport() {
 	h.viewport.SetContent(h.ContentView())
+	if h.contentAnchor >= 0 {
+		h.scrollToLine(h.contentAnchor)
+		return
+	}
 	h.viewport.GotoBottom()
 }
 
+// refreshViewport re-renders the content keeping the scroll position: only a
+// viewport already at the bottom follows it.
+func (h *DevTUI) refreshViewport() {
+	follow := h.viewport.AtBottom()
+	h.viewport.SetContent(h.ContentView())
+	if follow {
+		h.viewport.GotoBottom()
+	}
+}
+
+// scrollToLine scrolls the viewport the minimum needed to make line visible.
+func (h *DevTUI) scrollToLine(line int) {
+	switch {
+	case line < h.viewport.YOffset:
+		h.viewport.SetYOffset(line)
+	case h.viewport.Height > 0 && line >= h.viewport.YOffset+h.viewport.Height:
+		h.viewport.SetYOffset(line - h.viewport.Height + 1)
+	}
+}
+
 // RefreshUI updates the TUI display for the currently active tab.
 // This method is designed to be called from external tools/handlers to notify
 // devtui that the UI needs to be refreshed without creating coupling.
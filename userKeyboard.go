@@ -10,6 +10,15 @@ import (
 // handleKeyboard processes keyboard input and updates the model state
 // returns whether the update function should continue processing or return early
 func (h *DevTUI) handleKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if h.palette != nil { // Command palette overlay captures every key
+		return h.handlePaletteKeyboard(msg)
+	}
+	if h.help != nil { // Help overlay captures every key
+		return h.handleHelpKeyboard(msg)
+	}
+	if h.copyMode != nil { // Copy mode: keys move/extend the message selection
+		return h.handleCopyModeKeyboard(msg)
+	}
 	if h.editModeActivated { // EDITING CONFIG IN SECTION
 		return h.handleEditingConfigKeyboard(msg)
 	} else {
@@ -167,10 +176,15 @@ func (h *DevTUI) handleEditingConfigKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
 
 // handleNormalModeKeyboard handles keyboard input in normal mode (not editing config)
 func (h *DevTUI) handleNormalModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
-	if msg.Type == tea.KeyCtrlC {
-		if h.ClientMode && h.ClientURL != "" {
-			// Best-effort: tell daemon to stop the project
-			h.mcpClient().Dispatch(tinyctx.Background(), "tinywasm/action", &ActionArgs{Key: "stop"})
+	// Built-in keys are resolved through the keymap (DefaultKeymap + user keymap file)
+	action := h.keymap.action(keyStroke(msg))
+
+	if action == ActionQuit {
+		if h.ClientMode {
+			// Best-effort: tell every daemon to stop its project
+			for _, d := range h.daemons {
+				d.client.Dispatch(tinyctx.Background(), "tinywasm/action", &ActionArgs{Key: "stop"})
+			}
 		}
 		// Trigger shutdown through Update() to get full screen cleanup sequence
 		go h.tea.Send(shutdownMsg{})
@@ -185,21 +199,107 @@ func (h *DevTUI) handleNormalModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
 	fieldHandlers := currentTab.FieldHandlers
 	totalFields := len(fieldHandlers)
 
-	switch msg.Type {
-	case tea.KeyUp, tea.KeyDown:
+	if action == ActionPalette { // Command palette: fuzzy-find tabs, fields and shortcuts
+		h.openPalette()
+		return false, nil
+	}
+
+	if action == ActionHelp { // Shortcut help overlay, reachable from any tab
+		h.openHelp()
+		return false, nil
+	}
+
+	switch action {
+	case ActionCopyMode: // Select messages and copy their plain text (OSC 52)
+		h.enterCopyMode()
+		return false, nil
+	case ActionCopyField:
+		h.copyFieldValue()
+		return false, nil
+	case ActionPin:
+		h.pinSelection()
+		return false, nil
+	case ActionUnpinAll:
+		currentTab.clearPins()
+		h.updateViewport()
+		return false, nil
+	case ActionReconnect:
+		h.reconnectSSE()
+		return false, nil
+	case ActionTimings:
+		h.toggleTimings()
+		return false, nil
+	case ActionTimestamps:
+		h.cycleTimestampMode()
+		return false, nil
+	case ActionNextPin, ActionPrevPin:
+		if action == ActionNextPin {
+			h.jumpToPin(1)
+		} else {
+			h.jumpToPin(-1)
+		}
+		return false, nil
+	}
+
+	// Esc leaves the bookmark the log was scrolled to
+	if msg.Type == tea.KeyEsc && h.clearPinFocus() {
+		h.updateViewport()
+		return false, nil
+	}
+
+	if action == ActionMouse && h.Mouse { // Release/capture the mouse (text selection vs clicks)
+		return false, h.toggleMouse()
+	}
+
+	// Handler shortcuts: single keys, modifier keys and chords
+	if continueParsing, cmd, consumed := h.handleShortcutKey(msg); consumed {
+		return continueParsing, cmd
+	}
+
+	if currentTab.isOverview && h.handleOverviewKeyboard(action) {
+		return false, nil
+	}
+
+	// Alt+1..9 / F1..F12: jump directly to a tab (reserved in the shortcut registry)
+	if index := tabJumpIndex(msg); index >= 0 {
+		h.jumpToTab(index)
+		return false, nil
+	}
+
+	switch action {
+	case ActionScrollUp, ActionScrollDown:
+		// Grouped view: arrows move the selected handler header instead of scrolling
+		if currentTab.groupedView {
+			if action == ActionScrollUp {
+				h.moveGroupSelection(-1)
+			} else {
+				h.moveGroupSelection(1)
+			}
+			return false, nil
+		}
 		// Las teclas arriba y abajo controlan el scroll línea por línea del viewport
 		// No modifican el campo activo, solo el scroll del contenido
 		// No hacemos nada aquí para permitir que el manejo del viewport siga su curso normal
 
-	case tea.KeyPgUp: // Page Up - scroll página completa hacia arriba
+	case ActionToggleGroup: // Grouped view: expand/collapse the selected handler header
+		if currentTab.groupedView {
+			h.toggleSelectedGroup()
+			return false, nil
+		}
+
+	case ActionGroups: // Toggle grouped (collapsible handler headers) view for this tab
+		h.toggleGroupedView()
+		return false, nil
+
+	case ActionPageUp: // Page Up - scroll página completa hacia arriba
 		h.viewport.PageUp()
 		return false, nil
 
-	case tea.KeyPgDown: // Page Down - scroll página completa hacia abajo
+	case ActionPageDown: // Page Down - scroll página completa hacia abajo
 		h.viewport.PageDown()
 		return false, nil
 
-	case tea.KeyLeft: // Navegar al campo anterior (ciclo continuo)
+	case ActionPrevField: // Navegar al campo anterior (ciclo continuo)
 		if totalFields > 0 {
 			currentTab.IndexActiveEditField = (currentTab.IndexActiveEditField - 1 + totalFields) % totalFields
 			h.updateViewport()
@@ -207,7 +307,7 @@ func (h *DevTUI) handleNormalModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
 			return false, nil                     // Detener procesamiento adicional
 		}
 
-	case tea.KeyRight: // Navegar al campo siguiente (ciclo continuo)
+	case ActionNextField: // Navegar al campo siguiente (ciclo continuo)
 		if totalFields > 0 {
 			currentTab.IndexActiveEditField = (currentTab.IndexActiveEditField + 1) % totalFields
 			h.updateViewport()
@@ -215,19 +315,19 @@ func (h *DevTUI) handleNormalModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
 			return false, nil                     // Detener procesamiento adicional
 		}
 
-	case tea.KeyTab: // cambiar tabSection
+	case ActionNextTab: // cambiar tabSection
 		h.activeTab = (h.activeTab + 1) % len(h.TabSections)
 		h.notifyTabActive(h.activeTab)
 		h.updateViewport()
 		h.checkAndTriggerInteractiveContent() // NEW: Auto-trigger content for interactive handlers
 
-	case tea.KeyShiftTab: // cambiar tabSection
+	case ActionPrevTab: // cambiar tabSection
 		h.activeTab = (h.activeTab - 1 + len(h.TabSections)) % len(h.TabSections)
 		h.notifyTabActive(h.activeTab)
 		h.updateViewport()
 		h.checkAndTriggerInteractiveContent() // NEW: Auto-trigger content for interactive handlers
 
-	case tea.KeyEnter: //Enter para entrar en modo edición, ejecuta la acción directamente si el campo no es editable
+	case ActionEdit: //Enter para entrar en modo edición, ejecuta la acción directamente si el campo no es editable
 		if totalFields > 0 {
 			fieldHandlers := currentTab.FieldHandlers
 			field := fieldHandlers[currentTab.IndexActiveEditField]
@@ -246,14 +346,6 @@ func (h *DevTUI) handleNormalModeKeyboard(msg tea.KeyMsg) (bool, tea.Cmd) {
 			h.updateViewport()
 		}
 
-	case tea.KeyRunes: // NEW: Handle single character shortcuts
-		if len(msg.Runes) == 1 {
-			key := string(msg.Runes[0])
-			if entry, exists := h.shortcutRegistry.Get(key); exists {
-				return h.executeShortcut(entry)
-			}
-		}
-
 	}
 
 	return true, nil
@@ -308,14 +400,8 @@ func (h *DevTUI) executeShortcut(entry *ShortcutEntry) (bool, tea.Cmd) {
 
 	targetField := fieldHandlers[entry.FieldIndex]
 
-	// Navigate to target tab if not already there
-	if h.activeTab != entry.TabIndex {
-		h.activeTab = entry.TabIndex
-		h.notifyTabActive(h.activeTab)
-	}
-
-	// Set active field
-	targetTab.IndexActiveEditField = entry.FieldIndex
+	// Navigate to target tab and field
+	h.navigateTo(entry.TabIndex, entry.FieldIndex)
 
 	// Execute the Change method with shortcut value
 	if targetField.handler != nil {
@@ -329,3 +415,19 @@ func (h *DevTUI) executeShortcut(entry *ShortcutEntry) (bool, tea.Cmd) {
 
 	return false, nil // Stop further processing
 }
+
+// navigateTo activates tabIndex (notifying TabAware handlers when the tab changes)
+// and selects fieldIndex within it. A negative fieldIndex keeps the current field.
+func (h *DevTUI) navigateTo(tabIndex, fieldIndex int) {
+	if tabIndex < 0 || tabIndex >= len(h.TabSections) {
+		return
+	}
+	if h.activeTab != tabIndex {
+		h.activeTab = tabIndex
+		h.notifyTabActive(h.activeTab)
+	}
+	targetTab := h.TabSections[tabIndex]
+	if fieldIndex >= 0 && fieldIndex < len(targetTab.FieldHandlers) {
+		targetTab.IndexActiveEditField = fieldIndex
+	}
+}
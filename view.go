@@ -9,11 +9,19 @@ func (h *DevTUI) View() string {
 	if !h.ready {
 		return "\n  Initializing..."
 	}
-	return Sprintf("%s\n%s\n%s", h.headerView(), h.viewport.View(), h.footerView())
+	body := h.viewport.View()
+	if h.palette != nil {
+		body = h.paletteView()
+	} else if h.help != nil {
+		body = h.helpView()
+	}
+	return Sprintf("%s\n%s\n%s", h.headerView(), body, h.footerView())
 }
 
 // ContentView renderiza los mensajes para una sección de contenido
 func (h *DevTUI) ContentView() string {
+	h.contentAnchor = -1
+	h.contentLineIds = h.contentLineIds[:0]
 	if len(h.TabSections) == 0 {
 		return "No tabs created yet"
 	}
@@ -23,10 +31,10 @@ func (h *DevTUI) ContentView() string {
 
 	// Proteger el acceso a tabContents con mutex
 	section := h.TabSections[h.activeTab]
-	section.mu.RLock()
-	tabContent := make([]tabContent, len(section.tabContents)) // Copia para evitar retener el lock
-	copy(tabContent, section.tabContents)
-	section.mu.RUnlock()
+	if section.isOverview {
+		return h.overviewContent()
+	}
+	tabContent := section.contentsSnapshot() // Copia para evitar retener el lock
 
 	var contentLines []string
 
@@ -48,14 +56,82 @@ func (h *DevTUI) ContentView() string {
 		}
 	}
 
-	// Add regular tab content messages
-	for _, content := range tabContent {
-		formattedMsg := h.formatMessage(content, true)
-		contentLines = append(contentLines, h.textContentStyle.Render(formattedMsg))
+	// Timing stats and pinned messages stay on top, above the scrolling log
+	contentLines = append(contentLines, h.timingLines(section)...)
+	contentLines = append(contentLines, h.pinnedLines(section)...)
+
+	// Grouped view: one collapsible header per handler instead of a flat list
+	// (copy mode selects from the flat list)
+	if section.groupedView && h.copyMode == nil {
+		firstLine := 0
+		for _, l := range contentLines {
+			firstLine += lipgloss.Height(l)
+		}
+		contentLines = append(contentLines, h.groupedContentLines(section, tabContent, firstLine)...)
+		return Convert(contentLines).Join("\n").String()
+	}
+
+	// Add regular tab content messages, remembering which message owns each
+	// rendered line (mouse clicks expand its history, see mouse.go)
+	h.contentLineIds = h.contentLineIds[:0]
+	for _, l := range contentLines {
+		h.contentLineIds = appendLineOwner(h.contentLineIds, "", lipgloss.Height(l))
+	}
+	var selLo, selHi, selCursor int
+	if h.copyMode != nil && len(tabContent) > 0 {
+		selLo, selHi = h.copyMode.bounds(tabContent)
+		selCursor = h.copyMode.cursorIndex(tabContent)
+	}
+	for i, content := range tabContent {
+		formattedMsg := h.textContentStyle.Render(h.formatMessage(content, true))
+		if h.copyMode != nil {
+			formattedMsg = h.copyModeLine(formattedMsg, i, selLo, selHi, selCursor)
+		}
+		if h.copyMode == nil && section.isPinFocus(content) {
+			h.contentAnchor = len(h.contentLineIds)
+		}
+		contentLines = append(contentLines, formattedMsg)
+		h.contentLineIds = appendLineOwner(h.contentLineIds, content.Id, lipgloss.Height(formattedMsg))
+		history, versions := h.historyLines(section, content.Id)
+		for j, l := range history {
+			if h.copyMode == nil && section.isPinFocus(versions[j]) {
+				h.contentAnchor = len(h.contentLineIds)
+			}
+			contentLines = append(contentLines, l)
+			h.contentLineIds = appendLineOwner(h.contentLineIds, content.Id, lipgloss.Height(l))
+		}
 	}
 	return Convert(contentLines).Join("\n").String()
 }
 
+// fitBadges joins the header badges that fit in width; the ones left out are
+// counted in a trailing "+N".
+func (h *DevTUI) fitBadges(parts []string, width int) string {
+	out := ""
+	for i, part := range parts {
+		candidate := part
+		if out != "" {
+			candidate = out + " " + part
+		}
+		need := lipgloss.Width(candidate)
+		if rest := len(parts) - i - 1; rest > 0 {
+			need += len(Sprintf(" +%d", rest)) // room to count the rest if the next one doesn't fit
+		}
+		if need > width {
+			more := h.timeStyle.Render(Sprintf("+%d", len(parts)-i))
+			if out != "" {
+				more = out + " " + more
+			}
+			if lipgloss.Width(more) <= width {
+				return more
+			}
+			return out
+		}
+		out = candidate
+	}
+	return out
+}
+
 func (h *DevTUI) headerView() string {
 	if len(h.TabSections) == 0 {
 		return h.headerTitleStyle.Render(h.AppName + "/No tabs")
@@ -115,7 +191,23 @@ func (h *DevTUI) headerView() string {
 	// Also account for spacers (width 1 * 2)
 	horizontalPadding := 1
 	spacerStyle := lipgloss.NewStyle().Width(horizontalPadding).Render("")
-	lineWidth := h.viewport.Width - lipgloss.Width(title) - lipgloss.Width(paginationStyled) - horizontalPadding*2
+	// Connection badges, then the unread/error badges of background tabs, right
+	// before the pagination (the tab strip shows the latter next to each title
+	// instead). They are cut to the free width so the header stays one line.
+	parts := h.connectionBadges()
+	if !h.TabBar {
+		parts = append(parts, h.tabBadges()...)
+	}
+	free := h.viewport.Width - lipgloss.Width(title) - lipgloss.Width(paginationStyled) - horizontalPadding*3
+	badges := h.fitBadges(parts, free)
+	if badges != "" {
+		badges += spacerStyle
+	}
+	lineWidth := h.viewport.Width - lipgloss.Width(title) - lipgloss.Width(badges) - lipgloss.Width(paginationStyled) - horizontalPadding*2
 	line := h.lineHeadFootStyle.Render(Convert("─").Repeat(max(0, lineWidth)).String())
-	return lipgloss.JoinHorizontal(lipgloss.Center, title, spacerStyle, line, spacerStyle, paginationStyled)
+	header := lipgloss.JoinHorizontal(lipgloss.Center, title, spacerStyle, line, spacerStyle, badges, paginationStyled)
+	if h.TabBar {
+		header += "\n" + h.tabBarView()
+	}
+	return header
 }